@@ -16,77 +16,525 @@ const (
 	alterDropConstraint
 	alterRenameColumn
 	alterRenameConstraint
+	alterAddColumn
+	alterAlterColumn
+	alterAddConstraint
+	alterValidateConstraint
+	alterChangeColumn
+	alterSetSchema
+	// alterNone is used when an option is given before any action.
+	alterNone alter = -1
 )
 
 type alterData struct {
-	What      alter
-	TableName string
-	FirstKey  string
-	SecondKey string
-	Suffix    string
+	TableName  string
+	Schema     string
+	Actions    []alterAction
+	Suffix     string
+	Dialect    int
+	HasDialect bool
+	Current    *createData
+	Algorithm  DDLAlgorithm
+	Lock       DDLLock
+	Strict     bool
 }
 
-func init() {
-	builder.Register(AlterBuilder{}, alterData{})
+// alterAction is a single action of an ALTER TABLE statement.
+type alterAction struct {
+	What        alter
+	FirstKey    string
+	SecondKey   string
+	Column      ColumnDef
+	Position    string
+	IfNotExists bool
+	ColumnType  string
+	UsingExpr   string
+	Default     string
+	DropDefault bool
+	SetNotNull  bool
+	DropNotNull bool
+	Constraint  constraintDef
+	NotValid    bool
 }
 
-func (b AlterBuilder) whatToAlter(what alter) AlterBuilder {
-	return builder.Set(b, "What", what).(AlterBuilder)
+// alterPiece is a rendered part of an action. A clause is combined with the clauses
+// of other actions into a single ALTER TABLE statement when the dialect allows it,
+// unless it must stand alone. A statement is already a complete statement.
+// Pieces marked as last are rendered after every other piece. On MSSQL, consecutive
+// clauses of the same group share its prefix, such as ADD a INT, b INT.
+type alterPiece struct {
+	Clause    string
+	Alone     bool
+	Last      bool
+	Statement string
+	Group     string
+}
+
+func init() {
+	builder.Register(AlterBuilder{}, alterData{})
 }
 
 func (b AlterBuilder) tableName(table string) AlterBuilder {
 	return builder.Set(b, "TableName", table).(AlterBuilder)
 }
 
-func (b AlterBuilder) firstKey(key string) AlterBuilder {
-	return builder.Set(b, "FirstKey", key).(AlterBuilder)
+// actions returns a copy of the actions, so they can be modified without
+// touching the other builders.
+func (b AlterBuilder) actions() []alterAction {
+	a, _ := builder.Get(b, "Actions")
+	actions, _ := a.([]alterAction)
+	return append([]alterAction(nil), actions...)
+}
+
+// action appends a new action into the statement.
+func (b AlterBuilder) action(what alter, firstKey, secondKey string) AlterBuilder {
+	actions := append(b.actions(), alterAction{What: what, FirstKey: firstKey, SecondKey: secondKey})
+	return builder.Set(b, "Actions", actions).(AlterBuilder)
+}
+
+// modify modifies the last action of the statement.
+func (b AlterBuilder) modify(fn func(a *alterAction)) AlterBuilder {
+	actions := b.actions()
+	if len(actions) == 0 {
+		actions = append(actions, alterAction{What: alterNone})
+	}
+	fn(&actions[len(actions)-1])
+	return builder.Set(b, "Actions", actions).(AlterBuilder)
+}
+
+// DropColumn drops a column from the table.
+func (b AlterBuilder) DropColumn(column string) AlterBuilder {
+	return b.action(alterDropColumn, column, "")
+}
+
+// DropConstraint drops a constraint from the table.
+func (b AlterBuilder) DropConstraint(constraint string) AlterBuilder {
+	return b.action(alterDropConstraint, constraint, "")
+}
+
+// RenameColumn renames an existing column.
+func (b AlterBuilder) RenameColumn(from, to string) AlterBuilder {
+	return b.action(alterRenameColumn, from, to)
+}
+
+// ChangeColumn renames an existing column along with its definition, using MySQL's
+// CHANGE COLUMN. MySQL versions prior to 8.0 don't support RENAME COLUMN, so the whole
+// column definition must be given. The new position can be set with First or After.
+func (b AlterBuilder) ChangeColumn(from string, column ColumnDef) AlterBuilder {
+	actions := append(b.actions(), alterAction{What: alterChangeColumn, FirstKey: from, Column: column})
+	return builder.Set(b, "Actions", actions).(AlterBuilder)
 }
 
-func (b AlterBuilder) secondKey(key string) AlterBuilder {
-	return builder.Set(b, "SecondKey", key).(AlterBuilder)
+// RenameConstraint renames an existing constraint.
+func (b AlterBuilder) RenameConstraint(from, to string) AlterBuilder {
+	return b.action(alterRenameConstraint, from, to)
+}
+
+// SetSchema moves the table into another schema. It is rendered after every other action,
+// as ALTER TABLE ... SET SCHEMA on PostgreSQL, RENAME TABLE on MySQL and ALTER SCHEMA ... TRANSFER on MSSQL.
+func (b AlterBuilder) SetSchema(schema string) AlterBuilder {
+	return b.action(alterSetSchema, schema, "")
+}
+
+// WithSchema specifies the schema of the table.
+func (b AlterBuilder) WithSchema(schema string) AlterBuilder {
+	return builder.Set(b, "Schema", schema).(AlterBuilder)
 }
 
 func (b AlterBuilder) Suffix(any string) AlterBuilder {
 	return builder.Set(b, "Suffix", any).(AlterBuilder)
 }
 
+// Dialect specifies the database dialect the statement is rendered for.
+func (b AlterBuilder) Dialect(db int) AlterBuilder {
+	return builder.Set(b, "Dialect", db).(AlterBuilder).hasDialect()
+}
+
+func (b AlterBuilder) hasDialect() AlterBuilder {
+	return builder.Set(b, "HasDialect", true).(AlterBuilder)
+}
+
+// AddColumn adds a column into the table.
+// The column is rendered the same way as on CreateBuilder.
+func (b AlterBuilder) AddColumn(column ColumnDef) AlterBuilder {
+	return b.action(alterAddColumn, "", "").modify(func(a *alterAction) {
+		a.Column = column
+	})
+}
+
+// StringColumn adds a column with VARCHAR(255) data type.
+func (b AlterBuilder) StringColumn(name string, extras ...string) AlterBuilder {
+	return b.AddColumn(ColumnDef{Name: name, Type: "VARCHAR(255)", Extras: extras})
+}
+
+// TextColumn adds a column with TEXT data type.
+func (b AlterBuilder) TextColumn(name string, extras ...string) AlterBuilder {
+	return b.AddColumn(ColumnDef{Name: name, Type: "TEXT", Extras: extras})
+}
+
+// UUIDColumn adds a column with UUID data type. Only available for PostgreSQL.
+func (b AlterBuilder) UUIDColumn(name string, extras ...string) AlterBuilder {
+	return b.AddColumn(ColumnDef{Name: name, Type: "UUID", Extras: extras})
+}
+
+// BooleanColumn adds a column with BOOLEAN data type.
+func (b AlterBuilder) BooleanColumn(name string, extras ...string) AlterBuilder {
+	return b.AddColumn(ColumnDef{Name: name, Type: "BOOLEAN", Extras: extras})
+}
+
+// IntegerColumn adds a column with INTEGER data type. Only available for PostgreSQL and SQLite.
+func (b AlterBuilder) IntegerColumn(name string, extras ...string) AlterBuilder {
+	return b.AddColumn(ColumnDef{Name: name, Type: "INTEGER", Extras: extras})
+}
+
+// IntColumn adds a column with INT data type. Only available for MySQL and MSSQL.
+func (b AlterBuilder) IntColumn(name string, extras ...string) AlterBuilder {
+	return b.AddColumn(ColumnDef{Name: name, Type: "INT", Extras: extras})
+}
+
+// RealColumn adds a column with REAL data type. Only available for MSSQL, PostgreSQL, and SQLite.
+func (b AlterBuilder) RealColumn(name string, extras ...string) AlterBuilder {
+	return b.AddColumn(ColumnDef{Name: name, Type: "REAL", Extras: extras})
+}
+
+// FloatColumn adds a column with FLOAT data type. Only available for MySQL and MSSQL.
+func (b AlterBuilder) FloatColumn(name string, extras ...string) AlterBuilder {
+	return b.AddColumn(ColumnDef{Name: name, Type: "FLOAT", Extras: extras})
+}
+
+// DateTimeColumn adds a column with DATETIME data type.
+func (b AlterBuilder) DateTimeColumn(name string, extras ...string) AlterBuilder {
+	return b.AddColumn(ColumnDef{Name: name, Type: "DATETIME", Extras: extras})
+}
+
+// TimeStampColumn adds a column with TIMESTAMP data type.
+func (b AlterBuilder) TimeStampColumn(name string, extras ...string) AlterBuilder {
+	return b.AddColumn(ColumnDef{Name: name, Type: "TIMESTAMP", Extras: extras})
+}
+
+// TimeColumn adds a column with TIME data type.
+func (b AlterBuilder) TimeColumn(name string, extras ...string) AlterBuilder {
+	return b.AddColumn(ColumnDef{Name: name, Type: "TIME", Extras: extras})
+}
+
+// DateColumn adds a column with DATE data type.
+func (b AlterBuilder) DateColumn(name string, extras ...string) AlterBuilder {
+	return b.AddColumn(ColumnDef{Name: name, Type: "DATE", Extras: extras})
+}
+
+// JSONColumn adds a column with JSON data type. Only available for MySQL and PostgreSQL.
+func (b AlterBuilder) JSONColumn(name string, extras ...string) AlterBuilder {
+	return b.AddColumn(ColumnDef{Name: name, Type: "JSON", Extras: extras})
+}
+
+// JSONBColumn adds a column with JSONB data type. Only available for PostgreSQL.
+func (b AlterBuilder) JSONBColumn(name string, extras ...string) AlterBuilder {
+	return b.AddColumn(ColumnDef{Name: name, Type: "JSONB", Extras: extras})
+}
+
+// BlobColumn adds a column with BLOB data type. Only available for MySQL and SQLite.
+func (b AlterBuilder) BlobColumn(name string, extras ...string) AlterBuilder {
+	return b.AddColumn(ColumnDef{Name: name, Type: "BLOB", Extras: extras})
+}
+
+// First puts the added or changed column as the first column of the table. Only available for MySQL.
+func (b AlterBuilder) First() AlterBuilder {
+	return b.modify(func(a *alterAction) {
+		a.Position = "FIRST"
+	})
+}
+
+// After puts the added or changed column after an existing column. Only available for MySQL.
+func (b AlterBuilder) After(column string) AlterBuilder {
+	return b.modify(func(a *alterAction) {
+		a.Position = "AFTER \"" + column + "\""
+	})
+}
+
+// IfNotExists only adds the column if it doesn't exists. Only available for PostgreSQL.
+func (b AlterBuilder) IfNotExists() AlterBuilder {
+	return b.modify(func(a *alterAction) {
+		a.IfNotExists = true
+	})
+}
+
 func (b AlterBuilder) ToSql() (string, []interface{}, error) {
 	data := builder.GetStruct(b).(alterData)
 	return data.ToSql()
 }
 
+// ToStatements returns the query as a list of statements to be executed one by one.
+// Some dialects need more than one statement to alter a column.
+func (b AlterBuilder) ToStatements() ([]Statement, error) {
+	data := builder.GetStruct(b).(alterData)
+	return data.ToStatements()
+}
+
 func (d *alterData) ToSql() (sqlStr string, args []interface{}, err error) {
+	statements, err := d.ToStatements()
+	if err != nil {
+		return
+	}
+
+	sqlStr, args = joinStatements(statements)
+	return
+}
+
+func (d *alterData) ToStatements() (statements []Statement, err error) {
 	if d.TableName == "" {
 		err = errors.New("table name must not be empty")
 		return
 	}
 
-	if d.FirstKey == "" {
-		err = errors.New("the second argument must not be empty")
+	if len(d.Actions) == 0 {
+		err = errors.New("alter statement must have at least one action")
 		return
 	}
 
-	var sql strings.Builder
+	if d.HasDialect && !isDialectSupported(d.Dialect) {
+		err = ErrDialectNotSupported
+		return
+	}
 
-	sql.WriteString("ALTER TABLE ")
+	options, err := d.onlineDDL()
+	if err != nil {
+		return
+	}
+
+	if d.Current != nil && d.HasDialect && d.Dialect == SQLite && d.needsRebuild() {
+		return d.rebuild()
+	}
+
+	// Moving the table into another schema comes after everything else,
+	// as the other statements refer to the table on its current schema.
+	var pieces, lastPieces, movePieces []alterPiece
+	for _, action := range d.Actions {
+		var actionPieces []alterPiece
+		actionPieces, err = d.render(action)
+		if err != nil {
+			return
+		}
+		for _, piece := range actionPieces {
+			switch {
+			case action.What == alterSetSchema:
+				movePieces = append(movePieces, piece)
+			case piece.Last:
+				lastPieces = append(lastPieces, piece)
+			default:
+				pieces = append(pieces, piece)
+			}
+		}
+	}
+	pieces = append(pieces, lastPieces...)
+	pieces = append(pieces, movePieces...)
 
-	sql.WriteString(d.TableName + " ")
+	// SQLite only allows a single action per statement, so every action gets its own statement.
+	// MSSQL doesn't allow mixing different kinds of actions, but the columns added or dropped
+	// by consecutive actions are listed under a single ADD or DROP COLUMN.
+	combine := !d.HasDialect || d.Dialect == PostgreSQL || d.Dialect == MySQL
+	grouping := d.HasDialect && d.Dialect == MSSQL
+
+	var clauses []string
+	var group string
+	flush := func() {
+		if len(clauses) > 0 {
+			statements = append(statements, Statement{SQL: d.alterTable(strings.Join(append(clauses, options...), ", "))})
+			clauses = nil
+		}
+		group = ""
+	}
+
+	for _, piece := range pieces {
+		switch {
+		case piece.Statement != "":
+			flush()
+			statements = append(statements, Statement{SQL: piece.Statement})
+		case grouping && piece.Group != "" && piece.Group == group:
+			clauses = append(clauses, strings.TrimPrefix(piece.Clause, piece.Group))
+		case grouping && piece.Group != "" && !piece.Alone:
+			flush()
+			clauses = append(clauses, piece.Clause)
+			group = piece.Group
+		case piece.Alone || !combine:
+			flush()
+			clauses = append(clauses, piece.Clause)
+			flush()
+		default:
+			clauses = append(clauses, piece.Clause)
+		}
+	}
+	flush()
+
+	return
+}
+
+// render renders a single action into pieces of the statement.
+func (d *alterData) render(a alterAction) ([]alterPiece, error) {
+	if err := a.validate(); err != nil {
+		return nil, err
+	}
 
-	switch d.What {
+	if d.HasDialect && d.Dialect == SQLite && (a.What == alterDropConstraint || a.What == alterRenameConstraint) {
+		return nil, errors.New("SQLite doesn't support altering a constraint, the table must be rebuilt with Rebuild")
+	}
+
+	// PostgreSQL doesn't allow renames to be combined with other actions.
+	renameAlone := !d.HasDialect || d.Dialect == PostgreSQL
+
+	switch a.What {
 	case alterDropColumn:
-		sql.WriteString("DROP COLUMN " + d.FirstKey)
+		return []alterPiece{{Clause: "DROP COLUMN \"" + a.FirstKey + "\"", Group: "DROP COLUMN "}}, nil
 	case alterDropConstraint:
-		sql.WriteString("DROP CONSTRAINT " + d.FirstKey)
+		return []alterPiece{{Clause: "DROP CONSTRAINT " + a.FirstKey}}, nil
 	case alterRenameColumn:
-		sql.WriteString("RENAME COLUMN " + d.FirstKey + " TO " + d.SecondKey)
+		if d.HasDialect && d.Dialect == MSSQL {
+			return []alterPiece{{Statement: spRename(dotted(d.Schema, d.TableName, a.FirstKey), a.SecondKey, "COLUMN")}}, nil
+		}
+		return []alterPiece{{Clause: "RENAME COLUMN \"" + a.FirstKey + "\" TO \"" + a.SecondKey + "\"", Alone: renameAlone}}, nil
 	case alterRenameConstraint:
-		sql.WriteString("RENAME CONSTRAINT " + d.FirstKey + " TO " + d.SecondKey)
+		if d.HasDialect && d.Dialect == MSSQL {
+			return []alterPiece{{Statement: spRename(dotted(d.Schema, a.FirstKey), a.SecondKey, "OBJECT")}}, nil
+		}
+		return []alterPiece{{Clause: "RENAME CONSTRAINT " + a.FirstKey + " TO " + a.SecondKey, Alone: renameAlone}}, nil
+	case alterSetSchema:
+		return d.setSchema(a)
+	case alterChangeColumn:
+		clause, err := d.changeColumn(a)
+		if err != nil {
+			return nil, err
+		}
+		return []alterPiece{{Clause: clause}}, nil
+	case alterAddColumn:
+		clause, err := d.addColumn(a)
+		if err != nil {
+			return nil, err
+		}
+		return []alterPiece{{Clause: clause, Group: "ADD "}}, nil
+	case alterAlterColumn:
+		return d.alterColumn(a)
+	case alterAddConstraint:
+		return d.addConstraint(a)
+	case alterValidateConstraint:
+		clause, err := d.validateConstraint(a)
+		if err != nil {
+			return nil, err
+		}
+		return []alterPiece{{Clause: clause, Alone: true}}, nil
+	}
+
+	return nil, errors.New("unknown alter action")
+}
+
+// validate checks that the options of the action belong to it.
+func (a alterAction) validate() error {
+	if a.What == alterNone {
+		return errors.New("an action must be specified before its options")
+	}
+
+	if a.What != alterAddColumn && a.FirstKey == "" {
+		return errors.New("the second argument must not be empty")
 	}
 
+	if a.What != alterAddColumn && (a.IfNotExists || a.Position != "" && a.What != alterChangeColumn) {
+		return errors.New("First, After and IfNotExists must follow an added column")
+	}
+
+	if a.What != alterAlterColumn && (a.ColumnType != "" || a.UsingExpr != "" || a.Default != "" || a.DropDefault || a.SetNotNull || a.DropNotNull) {
+		return errors.New("column changes must follow AlterColumn")
+	}
+
+	if a.What != alterAddConstraint && (!a.Constraint.isEmpty() || a.NotValid) {
+		return errors.New("constraint options must follow an added constraint")
+	}
+
+	return nil
+}
+
+// table returns the quoted table name, prefixed with its schema if any.
+func (d *alterData) table() string {
+	return qualifiedName(d.Schema, d.TableName)
+}
+
+// alterTable wraps the clause into an ALTER TABLE statement.
+func (d *alterData) alterTable(clause string) string {
+	var sql strings.Builder
+
+	sql.WriteString("ALTER TABLE ")
+
+	sql.WriteString(d.table() + " ")
+
+	sql.WriteString(clause)
+
 	if d.Suffix != "" {
 		sql.WriteString(" " + d.Suffix)
 	}
 
-	sqlStr = sql.String()
-	return
+	return sql.String()
+}
+
+// addColumn renders the ADD COLUMN clause of the statement.
+func (d *alterData) addColumn(a alterAction) (string, error) {
+	if a.Column.Name == "" || a.Column.Type == "" {
+		return "", errors.New("the added column must have a name and a type")
+	}
+
+	if a.IfNotExists && d.HasDialect && d.Dialect != PostgreSQL {
+		return "", errors.New("ADD COLUMN IF NOT EXISTS is only available for PostgreSQL")
+	}
+
+	if a.Position != "" && d.HasDialect && d.Dialect != MySQL {
+		return "", errors.New("column positioning is only available for MySQL")
+	}
+
+	var sql strings.Builder
+
+	if d.HasDialect && d.Dialect == MSSQL {
+		sql.WriteString("ADD ")
+	} else {
+		sql.WriteString("ADD COLUMN ")
+	}
+
+	if a.IfNotExists {
+		sql.WriteString("IF NOT EXISTS ")
+	}
+
+	sql.WriteString(a.Column.render())
+
+	if a.Position != "" {
+		sql.WriteString(" " + a.Position)
+	}
+
+	return sql.String(), nil
+}
+
+// changeColumn renders the CHANGE COLUMN clause of the statement.
+func (d *alterData) changeColumn(a alterAction) (string, error) {
+	if d.HasDialect && d.Dialect != MySQL {
+		return "", errors.New("CHANGE COLUMN is only available for MySQL")
+	}
+
+	if a.Column.Name == "" || a.Column.Type == "" {
+		return "", errors.New("the changed column must have a name and a type")
+	}
+
+	clause := "CHANGE COLUMN \"" + a.FirstKey + "\" " + a.Column.render()
+	if a.Position != "" {
+		clause += " " + a.Position
+	}
+
+	return clause, nil
+}
+
+// setSchema renders the pieces to move the table into another schema.
+func (d *alterData) setSchema(a alterAction) ([]alterPiece, error) {
+	switch {
+	case !d.HasDialect || d.Dialect == PostgreSQL:
+		return []alterPiece{{Clause: "SET SCHEMA \"" + a.FirstKey + "\"", Alone: true}}, nil
+	case d.Dialect == MySQL:
+		return []alterPiece{{Statement: "RENAME TABLE " + d.table() + " TO " + qualifiedName(a.FirstKey, d.TableName) + ";"}}, nil
+	case d.Dialect == MSSQL:
+		return []alterPiece{{Statement: "ALTER SCHEMA \"" + a.FirstKey + "\" TRANSFER " + d.table() + ";"}}, nil
+	}
+
+	return nil, errors.New("SQLite doesn't support moving a table into another schema")
 }
@@ -0,0 +1,184 @@
+package bob
+
+import (
+	"errors"
+	"strings"
+)
+
+// AlterColumn changes an existing column.
+// Chain it with SetType, Using, SetDefault, DropDefault, SetNotNull or DropNotNull.
+func (b AlterBuilder) AlterColumn(column string) AlterBuilder {
+	return b.action(alterAlterColumn, column, "")
+}
+
+// SetType changes the data type of the column. MySQL and MSSQL replace the whole column definition,
+// so the nullability must be given along with SetNotNull or DropNotNull, and the existing default is
+// dropped unless it is given again with SetDefault.
+func (b AlterBuilder) SetType(columnType string) AlterBuilder {
+	return b.modify(func(a *alterAction) {
+		a.ColumnType = columnType
+	})
+}
+
+// Using specifies how the old values are converted into the new type.
+// Only available for PostgreSQL.
+func (b AlterBuilder) Using(expr string) AlterBuilder {
+	return b.modify(func(a *alterAction) {
+		a.UsingExpr = expr
+	})
+}
+
+// SetDefault changes the default value of the column. The value is written as is,
+// so string literals must be quoted.
+func (b AlterBuilder) SetDefault(value string) AlterBuilder {
+	return b.modify(func(a *alterAction) {
+		a.Default = value
+	})
+}
+
+// DropDefault removes the default value of the column.
+func (b AlterBuilder) DropDefault() AlterBuilder {
+	return b.modify(func(a *alterAction) {
+		a.DropDefault = true
+	})
+}
+
+// SetNotNull makes the column NOT NULL.
+func (b AlterBuilder) SetNotNull() AlterBuilder {
+	return b.modify(func(a *alterAction) {
+		a.SetNotNull = true
+	})
+}
+
+// DropNotNull makes the column nullable.
+func (b AlterBuilder) DropNotNull() AlterBuilder {
+	return b.modify(func(a *alterAction) {
+		a.DropNotNull = true
+	})
+}
+
+// alterColumn renders the statements to alter a column based on the dialect.
+func (d *alterData) alterColumn(a alterAction) ([]alterPiece, error) {
+	if err := a.validateChange(); err != nil {
+		return nil, err
+	}
+
+	if a.UsingExpr != "" && d.HasDialect && d.Dialect != PostgreSQL {
+		return nil, errors.New("USING is only available for PostgreSQL")
+	}
+
+	column := "\"" + a.FirstKey + "\""
+
+	switch {
+	case !d.HasDialect || d.Dialect == PostgreSQL:
+		// ALTER TABLE t ALTER COLUMN c TYPE x USING expr, ALTER COLUMN c SET DEFAULT v, ALTER COLUMN c SET NOT NULL
+		var pieces []alterPiece
+		if a.ColumnType != "" {
+			clause := "ALTER COLUMN " + column + " TYPE " + a.ColumnType
+			if a.UsingExpr != "" {
+				clause += " USING " + a.UsingExpr
+			}
+			pieces = append(pieces, alterPiece{Clause: clause})
+		}
+		if a.Default != "" {
+			pieces = append(pieces, alterPiece{Clause: "ALTER COLUMN " + column + " SET DEFAULT " + a.Default})
+		} else if a.DropDefault {
+			pieces = append(pieces, alterPiece{Clause: "ALTER COLUMN " + column + " DROP DEFAULT"})
+		}
+		if a.SetNotNull {
+			pieces = append(pieces, alterPiece{Clause: "ALTER COLUMN " + column + " SET NOT NULL"})
+		} else if a.DropNotNull {
+			pieces = append(pieces, alterPiece{Clause: "ALTER COLUMN " + column + " DROP NOT NULL"})
+		}
+		return pieces, nil
+
+	case d.Dialect == MySQL:
+		// MODIFY COLUMN needs the full column definition, which is why
+		// the nullability can only be changed along with the type.
+		if a.ColumnType == "" {
+			if a.SetNotNull || a.DropNotNull {
+				return nil, errors.New("MySQL requires the column type to change its nullability, please use SetType")
+			}
+			if a.DropDefault {
+				return []alterPiece{{Clause: "ALTER COLUMN " + column + " DROP DEFAULT"}}, nil
+			}
+			return []alterPiece{{Clause: "ALTER COLUMN " + column + " SET DEFAULT " + a.Default}}, nil
+		}
+		if !a.SetNotNull && !a.DropNotNull {
+			return nil, errors.New("MySQL replaces the whole column definition, please use SetNotNull or DropNotNull along with SetType")
+		}
+		return []alterPiece{{Clause: "MODIFY COLUMN " + a.definition(d.Dialect).render()}}, nil
+
+	case d.Dialect == MSSQL:
+		// Default values are constraints on MSSQL. The existing one has to be dropped
+		// before a new one is added or the column type is changed.
+		if a.ColumnType != "" && !a.SetNotNull && !a.DropNotNull {
+			return nil, errors.New("MSSQL replaces the whole column definition, please use SetNotNull or DropNotNull along with SetType")
+		}
+		var pieces []alterPiece
+		if a.ColumnType != "" || a.Default != "" || a.DropDefault {
+			pieces = append(pieces, alterPiece{Statement: d.dropDefaultConstraint(a)})
+		}
+		if a.ColumnType != "" {
+			pieces = append(pieces, alterPiece{Clause: "ALTER COLUMN " + a.definition(d.Dialect).render()})
+		} else if a.SetNotNull || a.DropNotNull {
+			return nil, errors.New("MSSQL requires the column type to change its nullability, please use SetType")
+		}
+		if a.Default != "" {
+			pieces = append(pieces, alterPiece{Clause: "ADD CONSTRAINT DF_" + d.TableName + "_" + a.FirstKey + " DEFAULT " + a.Default + " FOR " + column})
+		}
+		return pieces, nil
+
+	default:
+		return nil, errors.New("SQLite doesn't support altering a column, the table must be rebuilt with Rebuild")
+	}
+}
+
+// definition returns the altered column as a full column definition,
+// for the dialects that replace the whole definition.
+func (a alterAction) definition(dialect int) ColumnDef {
+	column := ColumnDef{Name: a.FirstKey, Type: a.ColumnType}
+	if a.SetNotNull {
+		column.Extras = append(column.Extras, "NOT NULL")
+	} else if a.DropNotNull {
+		column.Extras = append(column.Extras, "NULL")
+	}
+	if a.Default != "" && dialect == MySQL {
+		column.Extras = append(column.Extras, "DEFAULT "+a.Default)
+	}
+	return column
+}
+
+// validateChange checks that the column changes are consistent.
+func (a alterAction) validateChange() error {
+	if a.UsingExpr != "" && a.ColumnType == "" {
+		return errors.New("USING must be used along with SetType")
+	}
+
+	if a.ColumnType == "" && a.Default == "" && !a.DropDefault && !a.SetNotNull && !a.DropNotNull {
+		return errors.New("alter column statement must specify at least one change")
+	}
+
+	if a.Default != "" && a.DropDefault {
+		return errors.New("cannot set and drop the default value at the same time")
+	}
+
+	if a.SetNotNull && a.DropNotNull {
+		return errors.New("cannot set and drop NOT NULL at the same time")
+	}
+	return nil
+}
+
+// dropDefaultConstraint renders a MSSQL batch that looks up the name of the default constraint
+// on the column and drops it, if there is one. The variable is named after the column, so that
+// several columns can be altered in the same batch.
+func (d *alterData) dropDefaultConstraint(a alterAction) string {
+	variable := "@df_" + a.FirstKey
+	var sql strings.Builder
+	sql.WriteString("DECLARE " + variable + " NVARCHAR(256); ")
+	sql.WriteString("SELECT " + variable + " = QUOTENAME(dc.name) FROM sys.default_constraints dc ")
+	sql.WriteString("JOIN sys.columns c ON c.object_id = dc.parent_object_id AND c.column_id = dc.parent_column_id ")
+	sql.WriteString("WHERE dc.parent_object_id = OBJECT_ID(" + quoteString(d.table()) + ") AND c.name = " + quoteString(a.FirstKey) + "; ")
+	sql.WriteString("IF " + variable + " IS NOT NULL EXEC(" + quoteString("ALTER TABLE "+d.table()+" DROP CONSTRAINT ") + " + " + variable + ");")
+	return sql.String()
+}
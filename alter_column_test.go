@@ -0,0 +1,147 @@
+package bob_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aldy505/bob"
+)
+
+func TestAlterColumn(t *testing.T) {
+	t.Run("PostgreSQL", func(t *testing.T) {
+		sql, _, err := bob.
+			AlterColumn("users", "age").
+			Dialect(bob.PostgreSQL).
+			SetType("INTEGER").
+			Using("age::integer").
+			SetDefault("0").
+			SetNotNull().
+			ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected := "ALTER TABLE \"users\" ALTER COLUMN \"age\" TYPE INTEGER USING age::integer, ALTER COLUMN \"age\" SET DEFAULT 0, ALTER COLUMN \"age\" SET NOT NULL"
+		if sql != expected {
+			t.Fatalf("Expected %s, got %s", expected, sql)
+		}
+
+		sql, _, err = bob.AlterColumn("users", "age").DropDefault().DropNotNull().ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected = "ALTER TABLE \"users\" ALTER COLUMN \"age\" DROP DEFAULT, ALTER COLUMN \"age\" DROP NOT NULL"
+		if sql != expected {
+			t.Fatalf("Expected %s, got %s", expected, sql)
+		}
+	})
+
+	t.Run("MySQL", func(t *testing.T) {
+		sql, _, err := bob.
+			AlterColumn("users", "age").
+			Dialect(bob.MySQL).
+			SetType("BIGINT").
+			SetDefault("0").
+			DropNotNull().
+			ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected := "ALTER TABLE \"users\" MODIFY COLUMN \"age\" BIGINT NULL DEFAULT 0"
+		if sql != expected {
+			t.Fatalf("Expected %s, got %s", expected, sql)
+		}
+
+		sql, _, err = bob.AlterColumn("users", "age").Dialect(bob.MySQL).SetDefault("18").ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected = "ALTER TABLE \"users\" ALTER COLUMN \"age\" SET DEFAULT 18"
+		if sql != expected {
+			t.Fatalf("Expected %s, got %s", expected, sql)
+		}
+	})
+
+	t.Run("MSSQL", func(t *testing.T) {
+		statements, err := bob.
+			AlterColumn("users", "age").
+			Dialect(bob.MSSQL).
+			SetType("BIGINT").
+			SetNotNull().
+			SetDefault("0").
+			ToStatements()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected := []bob.Statement{
+			{SQL: "DECLARE @df_age NVARCHAR(256); SELECT @df_age = QUOTENAME(dc.name) FROM sys.default_constraints dc JOIN sys.columns c ON c.object_id = dc.parent_object_id AND c.column_id = dc.parent_column_id WHERE dc.parent_object_id = OBJECT_ID('\"users\"') AND c.name = 'age'; IF @df_age IS NOT NULL EXEC('ALTER TABLE \"users\" DROP CONSTRAINT ' + @df_age);"},
+			{SQL: "ALTER TABLE \"users\" ALTER COLUMN \"age\" BIGINT NOT NULL"},
+			{SQL: "ALTER TABLE \"users\" ADD CONSTRAINT DF_users_age DEFAULT 0 FOR \"age\""},
+		}
+		if !reflect.DeepEqual(statements, expected) {
+			t.Fatalf("Expected %v, got %v", expected, statements)
+		}
+
+		statements, err = bob.AlterColumn("users", "age").Dialect(bob.MSSQL).SetType("INT").DropNotNull().ToStatements()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected = []bob.Statement{
+			{SQL: "DECLARE @df_age NVARCHAR(256); SELECT @df_age = QUOTENAME(dc.name) FROM sys.default_constraints dc JOIN sys.columns c ON c.object_id = dc.parent_object_id AND c.column_id = dc.parent_column_id WHERE dc.parent_object_id = OBJECT_ID('\"users\"') AND c.name = 'age'; IF @df_age IS NOT NULL EXEC('ALTER TABLE \"users\" DROP CONSTRAINT ' + @df_age);"},
+			{SQL: "ALTER TABLE \"users\" ALTER COLUMN \"age\" INT NULL"},
+		}
+		if !reflect.DeepEqual(statements, expected) {
+			t.Fatalf("Expected %v, got %v", expected, statements)
+		}
+	})
+
+	t.Run("should alter several columns at once on MSSQL", func(t *testing.T) {
+		sql, _, err := bob.
+			AlterTable("users").
+			Dialect(bob.MSSQL).
+			AlterColumn("age").SetType("BIGINT").SetNotNull().
+			AlterColumn("score").SetType("INT").DropNotNull().
+			ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected := "DECLARE @df_age NVARCHAR(256); SELECT @df_age = QUOTENAME(dc.name) FROM sys.default_constraints dc JOIN sys.columns c ON c.object_id = dc.parent_object_id AND c.column_id = dc.parent_column_id WHERE dc.parent_object_id = OBJECT_ID('\"users\"') AND c.name = 'age'; IF @df_age IS NOT NULL EXEC('ALTER TABLE \"users\" DROP CONSTRAINT ' + @df_age); " +
+			"ALTER TABLE \"users\" ALTER COLUMN \"age\" BIGINT NOT NULL; " +
+			"DECLARE @df_score NVARCHAR(256); SELECT @df_score = QUOTENAME(dc.name) FROM sys.default_constraints dc JOIN sys.columns c ON c.object_id = dc.parent_object_id AND c.column_id = dc.parent_column_id WHERE dc.parent_object_id = OBJECT_ID('\"users\"') AND c.name = 'score'; IF @df_score IS NOT NULL EXEC('ALTER TABLE \"users\" DROP CONSTRAINT ' + @df_score); " +
+			"ALTER TABLE \"users\" ALTER COLUMN \"score\" INT NULL;"
+		if sql != expected {
+			t.Fatalf("Expected %s, got %s", expected, sql)
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		cases := []struct {
+			builder bob.AlterBuilder
+			err     string
+		}{
+			{bob.AlterColumn("users", "age"), "alter column statement must specify at least one change"},
+			{bob.AlterColumn("users", "age").SetDefault("0").DropDefault(), "cannot set and drop the default value at the same time"},
+			{bob.AlterColumn("users", "age").SetNotNull().DropNotNull(), "cannot set and drop NOT NULL at the same time"},
+			{bob.AlterColumn("users", "age").Using("age::integer"), "USING must be used along with SetType"},
+			{bob.AlterColumn("users", "age").Dialect(bob.MySQL).SetType("INT").Using("age"), "USING is only available for PostgreSQL"},
+			{bob.AlterColumn("users", "age").Dialect(bob.MySQL).SetNotNull(), "MySQL requires the column type to change its nullability, please use SetType"},
+			{bob.AlterColumn("users", "age").Dialect(bob.MSSQL).DropNotNull(), "MSSQL requires the column type to change its nullability, please use SetType"},
+			{bob.AlterColumn("users", "age").Dialect(bob.MySQL).SetType("INT"), "MySQL replaces the whole column definition, please use SetNotNull or DropNotNull along with SetType"},
+			{bob.AlterColumn("users", "age").Dialect(bob.MSSQL).SetType("INT").SetDefault("0"), "MSSQL replaces the whole column definition, please use SetNotNull or DropNotNull along with SetType"},
+			{bob.AlterColumn("users", "age").Dialect(bob.SQLite).SetType("INTEGER"), "SQLite doesn't support altering a column, the table must be rebuilt with Rebuild"},
+		}
+
+		for _, c := range cases {
+			_, _, err := c.builder.ToSql()
+			if err == nil || err.Error() != c.err {
+				t.Errorf("Expected error: %s. Got: %v", c.err, err)
+			}
+		}
+	})
+}
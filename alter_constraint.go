@@ -0,0 +1,252 @@
+package bob
+
+import (
+	"errors"
+	"strings"
+)
+
+type constraintType int
+
+const (
+	foreignKeyConstraint constraintType = iota
+	uniqueConstraint
+	checkConstraint
+	primaryKeyConstraint
+)
+
+// constraintDef is a table constraint that is added with ALTER TABLE.
+type constraintDef struct {
+	Type              constraintType
+	Columns           []string
+	RefTable          string
+	RefColumns        []string
+	OnDelete          string
+	OnUpdate          string
+	Check             string
+	Deferrable        bool
+	InitiallyDeferred bool
+}
+
+// constraint modifies the constraint of the last action.
+func (b AlterBuilder) constraint(fn func(c *constraintDef)) AlterBuilder {
+	return b.modify(func(a *alterAction) {
+		a.Constraint.Columns = append([]string(nil), a.Constraint.Columns...)
+		a.Constraint.RefColumns = append([]string(nil), a.Constraint.RefColumns...)
+		fn(&a.Constraint)
+	})
+}
+
+// addConstraint appends an action to add a constraint with the given type.
+func (b AlterBuilder) addConstraint(name string, t constraintType) AlterBuilder {
+	return b.action(alterAddConstraint, name, "").constraint(func(c *constraintDef) {
+		c.Type = t
+	})
+}
+
+// AddForeignKey adds a foreign key constraint.
+// Chain it with Columns, References, OnDelete and OnUpdate.
+func (b AlterBuilder) AddForeignKey(name string) AlterBuilder {
+	return b.addConstraint(name, foreignKeyConstraint)
+}
+
+// AddUnique adds a unique constraint. Chain it with Columns.
+func (b AlterBuilder) AddUnique(name string) AlterBuilder {
+	return b.addConstraint(name, uniqueConstraint)
+}
+
+// AddCheck adds a check constraint.
+func (b AlterBuilder) AddCheck(name, expr string) AlterBuilder {
+	return b.addConstraint(name, checkConstraint).constraint(func(c *constraintDef) {
+		c.Check = expr
+	})
+}
+
+// AddPrimaryKey adds a primary key constraint. Chain it with Columns.
+func (b AlterBuilder) AddPrimaryKey(name string) AlterBuilder {
+	return b.addConstraint(name, primaryKeyConstraint)
+}
+
+// ValidateConstraint validates a constraint that was added with NotValid.
+// Only available for PostgreSQL and MSSQL.
+func (b AlterBuilder) ValidateConstraint(name string) AlterBuilder {
+	return b.action(alterValidateConstraint, name, "")
+}
+
+// Columns sets the columns of the constraint that is going to be added.
+func (b AlterBuilder) Columns(columns ...string) AlterBuilder {
+	return b.constraint(func(c *constraintDef) {
+		c.Columns = append(c.Columns, columns...)
+	})
+}
+
+// References sets the referenced table and columns of a foreign key.
+// If no column is given, the primary key of the referenced table is used.
+func (b AlterBuilder) References(table string, columns ...string) AlterBuilder {
+	return b.constraint(func(c *constraintDef) {
+		c.RefTable = table
+		c.RefColumns = append(c.RefColumns, columns...)
+	})
+}
+
+// OnDelete sets the referential action of a foreign key when the referenced row is deleted,
+// for example "CASCADE" or "SET NULL".
+func (b AlterBuilder) OnDelete(action string) AlterBuilder {
+	return b.constraint(func(c *constraintDef) {
+		c.OnDelete = action
+	})
+}
+
+// OnUpdate sets the referential action of a foreign key when the referenced row is updated.
+func (b AlterBuilder) OnUpdate(action string) AlterBuilder {
+	return b.constraint(func(c *constraintDef) {
+		c.OnUpdate = action
+	})
+}
+
+// Deferrable makes the constraint DEFERRABLE. Only available for PostgreSQL.
+func (b AlterBuilder) Deferrable() AlterBuilder {
+	return b.constraint(func(c *constraintDef) {
+		c.Deferrable = true
+	})
+}
+
+// InitiallyDeferred makes the constraint DEFERRABLE INITIALLY DEFERRED,
+// the constraint is checked at the end of the transaction. Only available for PostgreSQL.
+func (b AlterBuilder) InitiallyDeferred() AlterBuilder {
+	return b.constraint(func(c *constraintDef) {
+		c.Deferrable = true
+		c.InitiallyDeferred = true
+	})
+}
+
+// NotValid adds a foreign key or check constraint without checking the existing rows,
+// so it doesn't hold a long lock on large tables.
+//
+// On PostgreSQL it is rendered as NOT VALID, followed by a VALIDATE CONSTRAINT statement
+// which can be executed separately with ToStatements. On MSSQL it is rendered as WITH NOCHECK.
+func (b AlterBuilder) NotValid() AlterBuilder {
+	return b.modify(func(a *alterAction) {
+		a.NotValid = true
+	})
+}
+
+// render returns the constraint definition, without the CONSTRAINT name prefix.
+func (c constraintDef) render() string {
+	var sql strings.Builder
+
+	switch c.Type {
+	case foreignKeyConstraint:
+		sql.WriteString("FOREIGN KEY (" + quoteColumns(c.Columns) + ") REFERENCES " + c.RefTable)
+		if len(c.RefColumns) > 0 {
+			sql.WriteString(" (" + quoteColumns(c.RefColumns) + ")")
+		}
+		if c.OnDelete != "" {
+			sql.WriteString(" ON DELETE " + c.OnDelete)
+		}
+		if c.OnUpdate != "" {
+			sql.WriteString(" ON UPDATE " + c.OnUpdate)
+		}
+	case uniqueConstraint:
+		sql.WriteString("UNIQUE (" + quoteColumns(c.Columns) + ")")
+	case checkConstraint:
+		sql.WriteString("CHECK (" + c.Check + ")")
+	case primaryKeyConstraint:
+		sql.WriteString("PRIMARY KEY (" + quoteColumns(c.Columns) + ")")
+	}
+
+	if c.Deferrable {
+		sql.WriteString(" DEFERRABLE")
+		if c.InitiallyDeferred {
+			sql.WriteString(" INITIALLY DEFERRED")
+		}
+	}
+
+	return sql.String()
+}
+
+// isEmpty checks whether any of the constraint options was given.
+func (c constraintDef) isEmpty() bool {
+	return len(c.Columns) == 0 && c.RefTable == "" && len(c.RefColumns) == 0 && c.OnDelete == "" &&
+		c.OnUpdate == "" && c.Check == "" && !c.Deferrable && !c.InitiallyDeferred
+}
+
+// validate checks the constraint definition regardless of the dialect.
+func (c constraintDef) validate() error {
+	switch c.Type {
+	case foreignKeyConstraint:
+		if len(c.Columns) == 0 || c.RefTable == "" {
+			return errors.New("a foreign key must have at least one column and a referenced table")
+		}
+		if len(c.RefColumns) > 0 && len(c.RefColumns) != len(c.Columns) {
+			return errors.New("a foreign key must reference the same number of columns")
+		}
+	case checkConstraint:
+		if c.Check == "" {
+			return errors.New("a check constraint must have an expression")
+		}
+	default:
+		if len(c.Columns) == 0 {
+			return errors.New("a constraint must have at least one column")
+		}
+	}
+
+	if c.Deferrable && c.Type == checkConstraint {
+		return errors.New("a check constraint cannot be deferrable")
+	}
+
+	return nil
+}
+
+// addConstraint renders the pieces to add a constraint based on the dialect.
+func (d *alterData) addConstraint(a alterAction) ([]alterPiece, error) {
+	if err := a.Constraint.validate(); err != nil {
+		return nil, err
+	}
+
+	if d.HasDialect && d.Dialect == SQLite {
+		return nil, errors.New("SQLite doesn't support adding a constraint, the table must be rebuilt with Rebuild")
+	}
+
+	if a.Constraint.Deferrable && d.HasDialect && d.Dialect != PostgreSQL {
+		return nil, errors.New("deferrable constraints are only available for PostgreSQL")
+	}
+
+	if a.NotValid {
+		if a.Constraint.Type != foreignKeyConstraint && a.Constraint.Type != checkConstraint {
+			return nil, errors.New("only foreign key and check constraints can be added without validation")
+		}
+		if d.HasDialect && d.Dialect != PostgreSQL && d.Dialect != MSSQL {
+			return nil, errors.New("adding a constraint without validation is only available for PostgreSQL and MSSQL")
+		}
+	}
+
+	clause := "ADD CONSTRAINT " + a.FirstKey + " " + a.Constraint.render()
+
+	if a.NotValid && d.HasDialect && d.Dialect == MSSQL {
+		return []alterPiece{{Clause: "WITH NOCHECK " + clause}}, nil
+	}
+
+	if a.NotValid {
+		// The validation gets its own statement, so it can be executed
+		// separately without holding the lock of the ALTER TABLE.
+		return []alterPiece{
+			{Clause: clause + " NOT VALID"},
+			{Clause: "VALIDATE CONSTRAINT " + a.FirstKey, Alone: true, Last: true},
+		}, nil
+	}
+
+	return []alterPiece{{Clause: clause}}, nil
+}
+
+// validateConstraint renders the clause to validate an existing constraint.
+func (d *alterData) validateConstraint(a alterAction) (string, error) {
+	if !d.HasDialect || d.Dialect == PostgreSQL {
+		return "VALIDATE CONSTRAINT " + a.FirstKey, nil
+	}
+
+	if d.Dialect == MSSQL {
+		return "WITH CHECK CHECK CONSTRAINT " + a.FirstKey, nil
+	}
+
+	return "", errors.New("validating a constraint is only available for PostgreSQL and MSSQL")
+}
@@ -0,0 +1,166 @@
+package bob_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aldy505/bob"
+)
+
+func TestAddConstraint(t *testing.T) {
+	t.Run("ForeignKey", func(t *testing.T) {
+		sql, _, err := bob.
+			AddForeignKey("posts", "fk_posts_user").
+			Columns("user_id").
+			References("users", "id").
+			OnDelete("CASCADE").
+			OnUpdate("NO ACTION").
+			ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected := "ALTER TABLE \"posts\" ADD CONSTRAINT fk_posts_user FOREIGN KEY (\"user_id\") REFERENCES users (\"id\") ON DELETE CASCADE ON UPDATE NO ACTION"
+		if sql != expected {
+			t.Fatalf("Expected %s, got %s", expected, sql)
+		}
+	})
+
+	t.Run("Unique", func(t *testing.T) {
+		sql, _, err := bob.AddUnique("users", "uq_users_email").Columns("tenant_id", "email").Dialect(bob.MySQL).ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected := "ALTER TABLE \"users\" ADD CONSTRAINT uq_users_email UNIQUE (\"tenant_id\", \"email\")"
+		if sql != expected {
+			t.Fatalf("Expected %s, got %s", expected, sql)
+		}
+	})
+
+	t.Run("Check", func(t *testing.T) {
+		sql, _, err := bob.AddCheck("users", "ck_users_age", "age >= 0").ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected := "ALTER TABLE \"users\" ADD CONSTRAINT ck_users_age CHECK (age >= 0)"
+		if sql != expected {
+			t.Fatalf("Expected %s, got %s", expected, sql)
+		}
+	})
+
+	t.Run("PrimaryKey", func(t *testing.T) {
+		sql, _, err := bob.AddPrimaryKey("memberships", "pk_memberships").Columns("tenant_id", "user_id").ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected := "ALTER TABLE \"memberships\" ADD CONSTRAINT pk_memberships PRIMARY KEY (\"tenant_id\", \"user_id\")"
+		if sql != expected {
+			t.Fatalf("Expected %s, got %s", expected, sql)
+		}
+	})
+
+	t.Run("Deferrable", func(t *testing.T) {
+		sql, _, err := bob.
+			AddForeignKey("posts", "fk_posts_user").
+			Dialect(bob.PostgreSQL).
+			Columns("user_id").
+			References("users").
+			InitiallyDeferred().
+			ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected := "ALTER TABLE \"posts\" ADD CONSTRAINT fk_posts_user FOREIGN KEY (\"user_id\") REFERENCES users DEFERRABLE INITIALLY DEFERRED"
+		if sql != expected {
+			t.Fatalf("Expected %s, got %s", expected, sql)
+		}
+	})
+
+	t.Run("NotValid on PostgreSQL", func(t *testing.T) {
+		statements, err := bob.
+			AddForeignKey("posts", "fk_posts_user").
+			Dialect(bob.PostgreSQL).
+			Columns("user_id").
+			References("users", "id").
+			NotValid().
+			ToStatements()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected := []bob.Statement{
+			{SQL: "ALTER TABLE \"posts\" ADD CONSTRAINT fk_posts_user FOREIGN KEY (\"user_id\") REFERENCES users (\"id\") NOT VALID"},
+			{SQL: "ALTER TABLE \"posts\" VALIDATE CONSTRAINT fk_posts_user"},
+		}
+		if !reflect.DeepEqual(statements, expected) {
+			t.Fatalf("Expected %v, got %v", expected, statements)
+		}
+	})
+
+	t.Run("NotValid on MSSQL", func(t *testing.T) {
+		sql, _, err := bob.AddCheck("users", "ck_users_age", "age >= 0").Dialect(bob.MSSQL).NotValid().ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected := "ALTER TABLE \"users\" WITH NOCHECK ADD CONSTRAINT ck_users_age CHECK (age >= 0)"
+		if sql != expected {
+			t.Fatalf("Expected %s, got %s", expected, sql)
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		cases := []struct {
+			builder bob.AlterBuilder
+			err     string
+		}{
+			{bob.AddForeignKey("posts", "fk").Columns("user_id"), "a foreign key must have at least one column and a referenced table"},
+			{bob.AddForeignKey("posts", "fk").Columns("user_id").References("users", "id", "tenant_id"), "a foreign key must reference the same number of columns"},
+			{bob.AddCheck("users", "ck", ""), "a check constraint must have an expression"},
+			{bob.AddUnique("users", "uq"), "a constraint must have at least one column"},
+			{bob.AddCheck("users", "ck", "age > 0").Deferrable(), "a check constraint cannot be deferrable"},
+			{bob.AddUnique("users", "uq").Columns("email").Dialect(bob.SQLite), "SQLite doesn't support adding a constraint, the table must be rebuilt with Rebuild"},
+			{bob.AddUnique("users", "uq").Columns("email").Dialect(bob.MySQL).Deferrable(), "deferrable constraints are only available for PostgreSQL"},
+			{bob.AddUnique("users", "uq").Columns("email").NotValid(), "only foreign key and check constraints can be added without validation"},
+			{bob.AddCheck("users", "ck", "age > 0").Dialect(bob.MySQL).NotValid(), "adding a constraint without validation is only available for PostgreSQL and MSSQL"},
+		}
+
+		for _, c := range cases {
+			_, _, err := c.builder.ToSql()
+			if err == nil || err.Error() != c.err {
+				t.Errorf("Expected error: %s. Got: %v", c.err, err)
+			}
+		}
+	})
+}
+
+func TestValidateConstraint(t *testing.T) {
+	sql, _, err := bob.ValidateConstraint("posts", "fk_posts_user").ToSql()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := "ALTER TABLE \"posts\" VALIDATE CONSTRAINT fk_posts_user"
+	if sql != expected {
+		t.Fatalf("Expected %s, got %s", expected, sql)
+	}
+
+	sql, _, err = bob.ValidateConstraint("posts", "fk_posts_user").Dialect(bob.MSSQL).ToSql()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected = "ALTER TABLE \"posts\" WITH CHECK CHECK CONSTRAINT fk_posts_user"
+	if sql != expected {
+		t.Fatalf("Expected %s, got %s", expected, sql)
+	}
+
+	_, _, err = bob.ValidateConstraint("posts", "fk_posts_user").Dialect(bob.MySQL).ToSql()
+	if err == nil || err.Error() != "validating a constraint is only available for PostgreSQL and MSSQL" {
+		t.Fatal("should throw an error, it didn't:", err)
+	}
+}
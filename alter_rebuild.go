@@ -0,0 +1,341 @@
+package bob
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/lann/builder"
+)
+
+// Rebuild provides the current definition of the table, which is needed on SQLite
+// for the actions that its ALTER TABLE doesn't support: altering a column, and adding,
+// dropping or renaming a constraint.
+//
+// When one of those actions is present, the table is rebuilt following the steps
+// documented on https://www.sqlite.org/lang_altertable.html: a new table is created with
+// the altered definition, the rows are copied, the old table is dropped, the new table
+// is renamed and the indexes of the current definition are recreated. Foreign keys are
+// turned off during the rebuild and checked before the transaction is committed.
+// Views and triggers that refer to the table are not recreated. A renamed column is renamed on the
+// constraints added with AddForeignKey, AddUnique and AddPrimaryKey, while the other constraints
+// referring to it must be dropped and added again. The constraints referring to a dropped column
+// must be dropped first.
+func (b AlterBuilder) Rebuild(current CreateBuilder) AlterBuilder {
+	data := builder.GetStruct(current).(createData)
+	return builder.Set(b, "Current", &data).(AlterBuilder)
+}
+
+// needsRebuild checks if any of the actions isn't supported by SQLite's ALTER TABLE.
+func (d *alterData) needsRebuild() bool {
+	for _, a := range d.Actions {
+		switch a.What {
+		case alterAlterColumn, alterAddConstraint, alterDropConstraint, alterRenameConstraint:
+			return true
+		}
+	}
+	return false
+}
+
+// rebuildColumn is a column of the rebuilt table, along with the expression
+// its values are copied from. Added columns have no source.
+type rebuildColumn struct {
+	Column ColumnDef
+	Source string
+}
+
+// rebuildConstraint is a constraint of the rebuilt table. The constraints added by the actions
+// keep their definition, so that their columns can be renamed.
+type rebuildConstraint struct {
+	Name       string
+	Definition string
+	Typed      *constraintDef
+}
+
+// refersTo checks if the constraint refers to a column of the table.
+func (c *rebuildConstraint) refersTo(table, column string) bool {
+	if c.Typed != nil && c.Typed.Type != checkConstraint {
+		return isIn(c.Typed.Columns, column) || c.isSelfReferencing(table) && isIn(c.Typed.RefColumns, column)
+	}
+	return regexp.MustCompile(`\b` + regexp.QuoteMeta(column) + `\b`).MatchString(c.Definition)
+}
+
+// isSelfReferencing checks if the constraint is a foreign key to its own table.
+func (c *rebuildConstraint) isSelfReferencing(table string) bool {
+	return c.Typed.RefTable == table || c.Typed.RefTable == "\""+table+"\""
+}
+
+// rename renames a column of the constraint. Raw definitions and check expressions can't be
+// rewritten safely, so an error is returned when they refer to the column.
+func (c *rebuildConstraint) rename(table, from, to string) error {
+	if c.Typed != nil && c.Typed.Type != checkConstraint {
+		c.Typed.Columns = renameIn(c.Typed.Columns, from, to)
+		if c.isSelfReferencing(table) {
+			c.Typed.RefColumns = renameIn(c.Typed.RefColumns, from, to)
+		}
+		c.Definition = c.Typed.render()
+		return nil
+	}
+
+	if c.refersTo(table, from) {
+		return errors.New("constraint " + c.Name + " refers to the renamed column " + from + ", it must be dropped and added again")
+	}
+	return nil
+}
+
+// rebuild renders the statements to rebuild the table with every action applied.
+func (d *alterData) rebuild() ([]Statement, error) {
+	current := *d.Current
+	if current.TableName != "" && current.TableName != d.TableName {
+		return nil, errors.New("the current table definition must be of the same table")
+	}
+	if d.Schema != "" {
+		current.Schema = d.Schema
+	}
+	if d.Suffix != "" {
+		return nil, errors.New("a suffix can't be added to the statements of a rebuilt table")
+	}
+
+	var columns []rebuildColumn
+	for _, column := range current.Columns {
+		columns = append(columns, rebuildColumn{Column: column, Source: "\"" + column.Name + "\""})
+	}
+
+	primaryKey := append([]string(nil), current.PrimaryKey...)
+	var constraints []rebuildConstraint
+	for _, constraint := range current.Constraints {
+		constraints = append(constraints, rebuildConstraint{Name: constraint.Name, Definition: constraint.Definition})
+	}
+	indexes := append([]createIndex(nil), current.Indexes...)
+
+	findColumn := func(name string) (int, error) {
+		for i, column := range columns {
+			if column.Column.Name == name {
+				return i, nil
+			}
+		}
+		return -1, errors.New("column " + name + " doesn't exist on the current table definition")
+	}
+
+	findConstraint := func(name string) (int, error) {
+		for i, constraint := range constraints {
+			if constraint.Name == name {
+				return i, nil
+			}
+		}
+		return -1, errors.New("constraint " + name + " doesn't exist on the current table definition")
+	}
+
+	for _, a := range d.Actions {
+		if err := a.validate(); err != nil {
+			return nil, err
+		}
+
+		switch a.What {
+		case alterAddColumn:
+			if _, err := d.addColumn(a); err != nil {
+				return nil, err
+			}
+			columns = append(columns, rebuildColumn{Column: a.Column})
+
+		case alterDropColumn:
+			i, err := findColumn(a.FirstKey)
+			if err != nil {
+				return nil, err
+			}
+			if isIn(primaryKey, a.FirstKey) {
+				return nil, errors.New("cannot drop column " + a.FirstKey + " as it is a part of the primary key")
+			}
+			for _, constraint := range constraints {
+				if constraint.refersTo(d.TableName, a.FirstKey) {
+					return nil, errors.New("constraint " + constraint.Name + " refers to the dropped column " + a.FirstKey + ", it must be dropped first")
+				}
+			}
+			columns = append(columns[:i:i], columns[i+1:]...)
+
+			// Indexes on the dropped column can't be recreated.
+			var kept []createIndex
+			for _, index := range indexes {
+				if !isIn(index.Columns, a.FirstKey) {
+					kept = append(kept, index)
+				}
+			}
+			indexes = kept
+
+		case alterRenameColumn:
+			i, err := findColumn(a.FirstKey)
+			if err != nil {
+				return nil, err
+			}
+			columns[i].Column.Name = a.SecondKey
+			primaryKey = renameIn(primaryKey, a.FirstKey, a.SecondKey)
+			for j := range indexes {
+				indexes[j].Columns = renameIn(indexes[j].Columns, a.FirstKey, a.SecondKey)
+			}
+			for j := range constraints {
+				if err := constraints[j].rename(d.TableName, a.FirstKey, a.SecondKey); err != nil {
+					return nil, err
+				}
+			}
+
+		case alterAlterColumn:
+			if err := a.validateChange(); err != nil {
+				return nil, err
+			}
+			i, err := findColumn(a.FirstKey)
+			if err != nil {
+				return nil, err
+			}
+			columns[i].Column = a.alterDefinition(columns[i].Column)
+			if a.UsingExpr != "" {
+				columns[i].Source = a.UsingExpr
+			}
+
+		case alterAddConstraint:
+			if err := a.Constraint.validate(); err != nil {
+				return nil, err
+			}
+			if a.NotValid {
+				return nil, errors.New("SQLite doesn't support adding a constraint without validation")
+			}
+			typed := a.Constraint
+			typed.Columns = append([]string(nil), typed.Columns...)
+			typed.RefColumns = append([]string(nil), typed.RefColumns...)
+			constraints = append(constraints, rebuildConstraint{Name: a.FirstKey, Definition: typed.render(), Typed: &typed})
+
+		case alterDropConstraint:
+			i, err := findConstraint(a.FirstKey)
+			if err != nil {
+				return nil, err
+			}
+			constraints = append(constraints[:i:i], constraints[i+1:]...)
+
+		case alterRenameConstraint:
+			i, err := findConstraint(a.FirstKey)
+			if err != nil {
+				return nil, err
+			}
+			constraints[i].Name = a.SecondKey
+
+		case alterValidateConstraint:
+			return nil, errors.New("validating a constraint is only available for PostgreSQL and MSSQL")
+
+		case alterChangeColumn:
+			return nil, errors.New("CHANGE COLUMN is only available for MySQL")
+
+		case alterSetSchema:
+			return nil, errors.New("SQLite doesn't support moving a table into another schema")
+		}
+	}
+
+	if len(columns) == 0 {
+		return nil, errors.New("a table should at least have one column")
+	}
+
+	newTable := current
+	newTable.TableName = "new_" + d.TableName
+	newTable.Dialect = SQLite
+	newTable.HasDialect = true
+	newTable.IfNotExists = false
+	newTable.Columns = nil
+	newTable.PrimaryKey = primaryKey
+	newTable.Constraints = nil
+	for _, constraint := range constraints {
+		newTable.Constraints = append(newTable.Constraints, createConstraint{Name: constraint.Name, Definition: constraint.Definition})
+	}
+	newTable.Indexes = nil
+
+	var targets, sources []string
+	for _, column := range columns {
+		newTable.Columns = append(newTable.Columns, column.Column)
+		if column.Source != "" {
+			targets = append(targets, "\""+column.Column.Name+"\"")
+			sources = append(sources, column.Source)
+		}
+	}
+
+	createTable, err := newTable.ToStatements()
+	if err != nil {
+		return nil, err
+	}
+
+	oldName := qualifiedName(current.Schema, d.TableName)
+	newName := qualifiedName(current.Schema, newTable.TableName)
+
+	statements := []Statement{
+		{SQL: "PRAGMA foreign_keys = OFF;"},
+		{SQL: "BEGIN TRANSACTION;"},
+	}
+	statements = append(statements, createTable...)
+	if len(targets) > 0 {
+		statements = append(statements, Statement{SQL: "INSERT INTO " + newName + " (" + strings.Join(targets, ", ") + ") SELECT " + strings.Join(sources, ", ") + " FROM " + oldName + ";"})
+	}
+	statements = append(statements,
+		Statement{SQL: "DROP TABLE " + oldName + ";"},
+		Statement{SQL: "ALTER TABLE " + newName + " RENAME TO \"" + d.TableName + "\";"},
+	)
+
+	if len(indexes) > 0 {
+		indexTable := current
+		indexTable.IfNotExists = false
+		indexTable.Dialect = SQLite
+		indexTable.HasDialect = true
+		indexTable.Indexes = indexes
+		indexTable.Columns = newTable.Columns
+		createIndexes, err := indexTable.ToStatements()
+		if err != nil {
+			return nil, err
+		}
+		// The first statement creates the table itself, which already exists.
+		statements = append(statements, createIndexes[1:]...)
+	}
+
+	statements = append(statements,
+		Statement{SQL: "PRAGMA foreign_key_check;"},
+		Statement{SQL: "COMMIT;"},
+		Statement{SQL: "PRAGMA foreign_keys = ON;"},
+	)
+
+	return statements, nil
+}
+
+// alterDefinition applies the column changes of the action into an existing column definition.
+func (a alterAction) alterDefinition(column ColumnDef) ColumnDef {
+	if a.ColumnType != "" {
+		column.Type = a.ColumnType
+	}
+
+	var extras []string
+	for _, extra := range column.Extras {
+		upper := strings.ToUpper(strings.TrimSpace(extra))
+		if (a.SetNotNull || a.DropNotNull) && (upper == "NOT NULL" || upper == "NULL") {
+			continue
+		}
+		if (a.Default != "" || a.DropDefault) && strings.HasPrefix(upper, "DEFAULT ") {
+			continue
+		}
+		extras = append(extras, extra)
+	}
+
+	if a.SetNotNull {
+		extras = append(extras, "NOT NULL")
+	}
+	if a.Default != "" {
+		extras = append(extras, "DEFAULT "+a.Default)
+	}
+
+	column.Extras = extras
+	return column
+}
+
+// renameIn replaces a value on a copy of the array.
+func renameIn(arr []string, from, to string) []string {
+	renamed := make([]string, len(arr))
+	for i, item := range arr {
+		if item == from {
+			item = to
+		}
+		renamed[i] = item
+	}
+	return renamed
+}
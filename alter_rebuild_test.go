@@ -0,0 +1,232 @@
+package bob_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aldy505/bob"
+)
+
+func TestAlterTable_Rebuild(t *testing.T) {
+	current := bob.
+		CreateTable("users").
+		IntegerColumn("id", "PRIMARY KEY").
+		TextColumn("name").
+		TextColumn("email", "NOT NULL").
+		IntegerColumn("age", "DEFAULT 0").
+		IntegerColumn("team_id").
+		Constraint("fk_users_team", "FOREIGN KEY (\"team_id\") REFERENCES teams (\"id\")").
+		UniqueIndex("idx_users_email", "email").
+		Index("idx_users_name", "name")
+
+	t.Run("should rebuild the table on unsupported actions", func(t *testing.T) {
+		statements, err := bob.
+			AlterTable("users").
+			Dialect(bob.SQLite).
+			Rebuild(current).
+			AlterColumn("age").SetType("REAL").Using("CAST(age AS REAL)").DropDefault().SetNotNull().
+			DropConstraint("fk_users_team").
+			AddCheck("ck_users_age", "age >= 0").
+			RenameColumn("name", "full_name").
+			TextColumn("nickname").
+			ToStatements()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected := []bob.Statement{
+			{SQL: "PRAGMA foreign_keys = OFF;"},
+			{SQL: "BEGIN TRANSACTION;"},
+			{SQL: "CREATE TABLE \"new_users\" (\"id\" INTEGER PRIMARY KEY, \"full_name\" TEXT, \"email\" TEXT NOT NULL, \"age\" REAL NOT NULL, \"team_id\" INTEGER, \"nickname\" TEXT, CONSTRAINT \"ck_users_age\" CHECK (age >= 0));"},
+			{SQL: "INSERT INTO \"new_users\" (\"id\", \"full_name\", \"email\", \"age\", \"team_id\") SELECT \"id\", \"name\", \"email\", CAST(age AS REAL), \"team_id\" FROM \"users\";"},
+			{SQL: "DROP TABLE \"users\";"},
+			{SQL: "ALTER TABLE \"new_users\" RENAME TO \"users\";"},
+			{SQL: "CREATE UNIQUE INDEX \"idx_users_email\" ON \"users\" (\"email\");"},
+			{SQL: "CREATE INDEX \"idx_users_name\" ON \"users\" (\"full_name\");"},
+			{SQL: "PRAGMA foreign_key_check;"},
+			{SQL: "COMMIT;"},
+			{SQL: "PRAGMA foreign_keys = ON;"},
+		}
+		if !reflect.DeepEqual(statements, expected) {
+			t.Fatalf("Expected %v, got %v", expected, statements)
+		}
+	})
+
+	t.Run("should rename the columns of the added constraints", func(t *testing.T) {
+		statements, err := bob.
+			AlterTable("users").
+			Dialect(bob.SQLite).
+			Rebuild(current).
+			AddUnique("uq_users_email").Columns("email").
+			RenameColumn("email", "mail").
+			ToStatements()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected := []bob.Statement{
+			{SQL: "PRAGMA foreign_keys = OFF;"},
+			{SQL: "BEGIN TRANSACTION;"},
+			{SQL: "CREATE TABLE \"new_users\" (\"id\" INTEGER PRIMARY KEY, \"name\" TEXT, \"mail\" TEXT NOT NULL, \"age\" INTEGER DEFAULT 0, \"team_id\" INTEGER, CONSTRAINT \"fk_users_team\" FOREIGN KEY (\"team_id\") REFERENCES teams (\"id\"), CONSTRAINT \"uq_users_email\" UNIQUE (\"mail\"));"},
+			{SQL: "INSERT INTO \"new_users\" (\"id\", \"name\", \"mail\", \"age\", \"team_id\") SELECT \"id\", \"name\", \"email\", \"age\", \"team_id\" FROM \"users\";"},
+			{SQL: "DROP TABLE \"users\";"},
+			{SQL: "ALTER TABLE \"new_users\" RENAME TO \"users\";"},
+			{SQL: "CREATE UNIQUE INDEX \"idx_users_email\" ON \"users\" (\"mail\");"},
+			{SQL: "CREATE INDEX \"idx_users_name\" ON \"users\" (\"name\");"},
+			{SQL: "PRAGMA foreign_key_check;"},
+			{SQL: "COMMIT;"},
+			{SQL: "PRAGMA foreign_keys = ON;"},
+		}
+		if !reflect.DeepEqual(statements, expected) {
+			t.Fatalf("Expected %v, got %v", expected, statements)
+		}
+	})
+
+	t.Run("should emit error on renaming a column of a raw constraint", func(t *testing.T) {
+		_, err := bob.
+			AlterTable("users").
+			Dialect(bob.SQLite).
+			Rebuild(current).
+			AddCheck("ck_users_age", "age >= 0").
+			RenameColumn("team_id", "group_id").
+			ToStatements()
+		if err == nil || err.Error() != "constraint fk_users_team refers to the renamed column team_id, it must be dropped and added again" {
+			t.Fatal("should throw an error, it didn't:", err)
+		}
+	})
+
+	t.Run("should qualify the index names with the schema", func(t *testing.T) {
+		statements, err := bob.
+			AlterTable("users").
+			WithSchema("private").
+			Dialect(bob.SQLite).
+			Rebuild(current).
+			DropConstraint("fk_users_team").
+			ToStatements()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected := []bob.Statement{
+			{SQL: "PRAGMA foreign_keys = OFF;"},
+			{SQL: "BEGIN TRANSACTION;"},
+			{SQL: "CREATE TABLE \"private\".\"new_users\" (\"id\" INTEGER PRIMARY KEY, \"name\" TEXT, \"email\" TEXT NOT NULL, \"age\" INTEGER DEFAULT 0, \"team_id\" INTEGER);"},
+			{SQL: "INSERT INTO \"private\".\"new_users\" (\"id\", \"name\", \"email\", \"age\", \"team_id\") SELECT \"id\", \"name\", \"email\", \"age\", \"team_id\" FROM \"private\".\"users\";"},
+			{SQL: "DROP TABLE \"private\".\"users\";"},
+			{SQL: "ALTER TABLE \"private\".\"new_users\" RENAME TO \"users\";"},
+			{SQL: "CREATE UNIQUE INDEX \"private\".\"idx_users_email\" ON \"users\" (\"email\");"},
+			{SQL: "CREATE INDEX \"private\".\"idx_users_name\" ON \"users\" (\"name\");"},
+			{SQL: "PRAGMA foreign_key_check;"},
+			{SQL: "COMMIT;"},
+			{SQL: "PRAGMA foreign_keys = ON;"},
+		}
+		if !reflect.DeepEqual(statements, expected) {
+			t.Fatalf("Expected %v, got %v", expected, statements)
+		}
+	})
+
+	t.Run("should drop indexes of a dropped column", func(t *testing.T) {
+		statements, err := bob.
+			AlterTable("users").
+			Dialect(bob.SQLite).
+			Rebuild(current).
+			DropColumn("name").
+			AddForeignKey("fk_users_team_v2").Columns("team_id").References("teams", "id").OnDelete("CASCADE").
+			ToStatements()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected := []bob.Statement{
+			{SQL: "PRAGMA foreign_keys = OFF;"},
+			{SQL: "BEGIN TRANSACTION;"},
+			{SQL: "CREATE TABLE \"new_users\" (\"id\" INTEGER PRIMARY KEY, \"email\" TEXT NOT NULL, \"age\" INTEGER DEFAULT 0, \"team_id\" INTEGER, CONSTRAINT \"fk_users_team\" FOREIGN KEY (\"team_id\") REFERENCES teams (\"id\"), CONSTRAINT \"fk_users_team_v2\" FOREIGN KEY (\"team_id\") REFERENCES teams (\"id\") ON DELETE CASCADE);"},
+			{SQL: "INSERT INTO \"new_users\" (\"id\", \"email\", \"age\", \"team_id\") SELECT \"id\", \"email\", \"age\", \"team_id\" FROM \"users\";"},
+			{SQL: "DROP TABLE \"users\";"},
+			{SQL: "ALTER TABLE \"new_users\" RENAME TO \"users\";"},
+			{SQL: "CREATE UNIQUE INDEX \"idx_users_email\" ON \"users\" (\"email\");"},
+			{SQL: "PRAGMA foreign_key_check;"},
+			{SQL: "COMMIT;"},
+			{SQL: "PRAGMA foreign_keys = ON;"},
+		}
+		if !reflect.DeepEqual(statements, expected) {
+			t.Fatalf("Expected %v, got %v", expected, statements)
+		}
+	})
+
+	t.Run("should drop a column once the constraints referring to it are dropped", func(t *testing.T) {
+		statements, err := bob.
+			AlterTable("users").
+			Dialect(bob.SQLite).
+			Rebuild(current).
+			DropConstraint("fk_users_team").
+			DropColumn("team_id").
+			ToStatements()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected := []bob.Statement{
+			{SQL: "PRAGMA foreign_keys = OFF;"},
+			{SQL: "BEGIN TRANSACTION;"},
+			{SQL: "CREATE TABLE \"new_users\" (\"id\" INTEGER PRIMARY KEY, \"name\" TEXT, \"email\" TEXT NOT NULL, \"age\" INTEGER DEFAULT 0);"},
+			{SQL: "INSERT INTO \"new_users\" (\"id\", \"name\", \"email\", \"age\") SELECT \"id\", \"name\", \"email\", \"age\" FROM \"users\";"},
+			{SQL: "DROP TABLE \"users\";"},
+			{SQL: "ALTER TABLE \"new_users\" RENAME TO \"users\";"},
+			{SQL: "CREATE UNIQUE INDEX \"idx_users_email\" ON \"users\" (\"email\");"},
+			{SQL: "CREATE INDEX \"idx_users_name\" ON \"users\" (\"name\");"},
+			{SQL: "PRAGMA foreign_key_check;"},
+			{SQL: "COMMIT;"},
+			{SQL: "PRAGMA foreign_keys = ON;"},
+		}
+		if !reflect.DeepEqual(statements, expected) {
+			t.Fatalf("Expected %v, got %v", expected, statements)
+		}
+	})
+
+	t.Run("should use ALTER TABLE \"when\" the actions are supported", func(t *testing.T) {
+		sql, _, err := bob.
+			AlterTable("users").
+			Dialect(bob.SQLite).
+			Rebuild(current).
+			TextColumn("nickname").
+			ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected := "ALTER TABLE \"users\" ADD COLUMN \"nickname\" TEXT"
+		if sql != expected {
+			t.Fatalf("Expected %s, got %s", expected, sql)
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		cases := []struct {
+			builder bob.AlterBuilder
+			err     string
+		}{
+			{bob.DropConstraint("users", "fk_users_team").Dialect(bob.SQLite), "SQLite doesn't support altering a constraint, the table must be rebuilt with Rebuild"},
+			{bob.DropConstraint("users", "fk_unknown").Dialect(bob.SQLite).Rebuild(current), "constraint fk_unknown doesn't exist on the current table definition"},
+			{bob.AlterColumn("users", "unknown").SetNotNull().Dialect(bob.SQLite).Rebuild(current), "column unknown doesn't exist on the current table definition"},
+			{bob.AlterColumn("users", "age").SetNotNull().Dialect(bob.SQLite).Rebuild(bob.CreateTable("people").IntegerColumn("age")), "the current table definition must be of the same table"},
+			{bob.AlterTable("users").Dialect(bob.SQLite).Rebuild(bob.CreateTable("users").IntegerColumn("id").IntegerColumn("tenant_id").PrimaryKey("tenant_id", "id")).DropColumn("id").AlterColumn("tenant_id").SetNotNull(), "cannot drop column id as it is a part of the primary key"},
+			{bob.AddCheck("users", "ck", "age > 0").Dialect(bob.SQLite).Rebuild(current).NotValid(), "SQLite doesn't support adding a constraint without validation"},
+			{bob.DropConstraint("users", "fk_users_team").Dialect(bob.SQLite).Rebuild(current).TextColumn("nickname").First(), "column positioning is only available for MySQL"},
+			{bob.DropConstraint("users", "fk_users_team").Dialect(bob.SQLite).Rebuild(current).TextColumn("nickname").IfNotExists(), "ADD COLUMN IF NOT EXISTS is only available for PostgreSQL"},
+			{bob.DropConstraint("users", "fk_users_team").Dialect(bob.SQLite).Rebuild(current).After("id"), "First, After and IfNotExists must follow an added column"},
+			{bob.DropConstraint("users", "fk_users_team").Dialect(bob.SQLite).Rebuild(current).Suffix("CASCADE"), "a suffix can't be added to the statements of a rebuilt table"},
+			{bob.DropConstraint("users", "fk_users_team").Dialect(bob.SQLite).Rebuild(current).Algorithm(bob.AlgorithmCopy), "ALGORITHM and LOCK are only available for MySQL"},
+			{bob.DropConstraint("users", "fk_users_team").Dialect(bob.SQLite).Rebuild(current).AlterColumn("age"), "alter column statement must specify at least one change"},
+			{bob.AlterColumn("users", "age").SetNotNull().Dialect(bob.SQLite).Rebuild(current).DropColumn("team_id"), "constraint fk_users_team refers to the dropped column team_id, it must be dropped first"},
+			{bob.AddUnique("users", "uq_users_email").Columns("email").Dialect(bob.SQLite).Rebuild(current).DropColumn("email"), "constraint uq_users_email refers to the dropped column email, it must be dropped first"},
+		}
+
+		for _, c := range cases {
+			_, _, err := c.builder.ToSql()
+			if err == nil || err.Error() != c.err {
+				t.Errorf("Expected error: %s. Got: %v", c.err, err)
+			}
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package bob_test
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/aldy505/bob"
@@ -13,7 +14,7 @@ func TestDropColumn(t *testing.T) {
 			t.Fatal(err.Error())
 		}
 
-		expected := "ALTER TABLE users DROP COLUMN name"
+		expected := "ALTER TABLE \"users\" DROP COLUMN \"name\""
 		if sql != expected {
 			t.Fatalf("Expected %s, got %s", expected, sql)
 		}
@@ -25,7 +26,7 @@ func TestDropColumn(t *testing.T) {
 			t.Fatal(err.Error())
 		}
 
-		expected := "ALTER TABLE users DROP COLUMN name CASCADE"
+		expected := "ALTER TABLE \"users\" DROP COLUMN \"name\" CASCADE"
 		if sql != expected {
 			t.Fatalf("Got: %s, Expected: %s", sql, expected)
 		}
@@ -39,7 +40,7 @@ func TestDropConstraint(t *testing.T) {
 			t.Fatal(err.Error())
 		}
 
-		expected := "ALTER TABLE users DROP CONSTRAINT name"
+		expected := "ALTER TABLE \"users\" DROP CONSTRAINT name"
 		if sql != expected {
 			t.Fatalf("Expected %s, got %s", expected, sql)
 		}
@@ -51,7 +52,7 @@ func TestDropConstraint(t *testing.T) {
 			t.Fatal(err.Error())
 		}
 
-		expected := "ALTER TABLE users DROP CONSTRAINT name CASCADE"
+		expected := "ALTER TABLE \"users\" DROP CONSTRAINT name CASCADE"
 		if sql != expected {
 			t.Fatalf("Got: %s, Expected: %s", sql, expected)
 		}
@@ -65,7 +66,7 @@ func TestRenameColumn(t *testing.T) {
 			t.Fatal(err.Error())
 		}
 
-		expected := "ALTER TABLE users RENAME COLUMN name TO full_name"
+		expected := "ALTER TABLE \"users\" RENAME COLUMN \"name\" TO \"full_name\""
 		if sql != expected {
 			t.Fatalf("Expected %s, got %s", expected, sql)
 		}
@@ -77,11 +78,50 @@ func TestRenameColumn(t *testing.T) {
 			t.Fatal(err.Error())
 		}
 
-		expected := "ALTER TABLE users RENAME COLUMN name TO full_name CASCADE"
+		expected := "ALTER TABLE \"users\" RENAME COLUMN \"name\" TO \"full_name\" CASCADE"
 		if sql != expected {
 			t.Fatalf("Got: %s, Expected: %s", sql, expected)
 		}
 	})
+
+	t.Run("MSSQL", func(t *testing.T) {
+		sql, _, err := bob.RenameColumn("users", "name", "full_name").Dialect(bob.MSSQL).ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected := "EXEC sp_rename 'users.name', 'full_name', 'COLUMN';"
+		if sql != expected {
+			t.Fatalf("Expected %s, got %s", expected, sql)
+		}
+	})
+
+	t.Run("MySQL CHANGE COLUMN", func(t *testing.T) {
+		sql, _, err := bob.
+			AlterTable("users").
+			Dialect(bob.MySQL).
+			ChangeColumn("name", bob.ColumnDef{Name: "full_name", Type: "VARCHAR(255)", Extras: []string{"NOT NULL"}}).After("id").
+			ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected := "ALTER TABLE \"users\" CHANGE COLUMN \"name\" \"full_name\" VARCHAR(255) NOT NULL AFTER \"id\""
+		if sql != expected {
+			t.Fatalf("Expected %s, got %s", expected, sql)
+		}
+	})
+
+	t.Run("CHANGE COLUMN on other dialects", func(t *testing.T) {
+		_, _, err := bob.
+			AlterTable("users").
+			Dialect(bob.PostgreSQL).
+			ChangeColumn("name", bob.ColumnDef{Name: "full_name", Type: "TEXT"}).
+			ToSql()
+		if err == nil || err.Error() != "CHANGE COLUMN is only available for MySQL" {
+			t.Fatal("should throw an error, it didn't:", err)
+		}
+	})
 }
 
 func TestRenameConstraint(t *testing.T) {
@@ -91,7 +131,7 @@ func TestRenameConstraint(t *testing.T) {
 			t.Fatal(err.Error())
 		}
 
-		expected := "ALTER TABLE users RENAME CONSTRAINT name TO full_name"
+		expected := "ALTER TABLE \"users\" RENAME CONSTRAINT name TO full_name"
 		if sql != expected {
 			t.Fatalf("Expected %s, got %s", expected, sql)
 		}
@@ -103,11 +143,23 @@ func TestRenameConstraint(t *testing.T) {
 			t.Fatal(err.Error())
 		}
 
-		expected := "ALTER TABLE users RENAME CONSTRAINT name TO full_name CASCADE"
+		expected := "ALTER TABLE \"users\" RENAME CONSTRAINT name TO full_name CASCADE"
 		if sql != expected {
 			t.Fatalf("Got: %s, Expected: %s", sql, expected)
 		}
 	})
+
+	t.Run("MSSQL", func(t *testing.T) {
+		sql, _, err := bob.RenameConstraint("users", "fk_users_team", "fk_users_teams").Dialect(bob.MSSQL).ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected := "EXEC sp_rename 'fk_users_team', 'fk_users_teams', 'OBJECT';"
+		if sql != expected {
+			t.Fatalf("Expected %s, got %s", expected, sql)
+		}
+	})
 }
 
 func TestAlter_Error(t *testing.T) {
@@ -121,3 +173,246 @@ func TestAlter_Error(t *testing.T) {
 		t.Fatal("Expected error: the second argument must not be empty. Got:", err.Error())
 	}
 }
+
+func TestAddColumn(t *testing.T) {
+	t.Run("Plain", func(t *testing.T) {
+		sql, _, err := bob.AddColumn("users", bob.ColumnDef{Name: "email", Type: "VARCHAR(120)", Extras: []string{"NOT NULL"}}).ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected := "ALTER TABLE \"users\" ADD COLUMN \"email\" VARCHAR(120) NOT NULL"
+		if sql != expected {
+			t.Fatalf("Expected %s, got %s", expected, sql)
+		}
+	})
+
+	t.Run("Typed", func(t *testing.T) {
+		sql, _, err := bob.AlterTable("users").Dialect(bob.PostgreSQL).JSONBColumn("settings").IfNotExists().ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected := "ALTER TABLE \"users\" ADD COLUMN IF NOT EXISTS \"settings\" JSONB"
+		if sql != expected {
+			t.Fatalf("Expected %s, got %s", expected, sql)
+		}
+	})
+
+	t.Run("MySQL", func(t *testing.T) {
+		sql, _, err := bob.AlterTable("users").Dialect(bob.MySQL).StringColumn("email").After("id").ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected := "ALTER TABLE \"users\" ADD COLUMN \"email\" VARCHAR(255) AFTER \"id\""
+		if sql != expected {
+			t.Fatalf("Expected %s, got %s", expected, sql)
+		}
+
+		sql, _, err = bob.AlterTable("users").Dialect(bob.MySQL).IntColumn("id").First().ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected = "ALTER TABLE \"users\" ADD COLUMN \"id\" INT FIRST"
+		if sql != expected {
+			t.Fatalf("Expected %s, got %s", expected, sql)
+		}
+	})
+
+	t.Run("MSSQL", func(t *testing.T) {
+		sql, _, err := bob.AlterTable("users").Dialect(bob.MSSQL).IntColumn("age", "NULL").ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected := "ALTER TABLE \"users\" ADD \"age\" INT NULL"
+		if sql != expected {
+			t.Fatalf("Expected %s, got %s", expected, sql)
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		_, _, err := bob.AddColumn("users", bob.ColumnDef{Name: "email"}).ToSql()
+		if err == nil || err.Error() != "the added column must have a name and a type" {
+			t.Fatal("should throw an error, it didn't:", err)
+		}
+
+		_, _, err = bob.AlterTable("users").Dialect(bob.SQLite).TextColumn("email").IfNotExists().ToSql()
+		if err == nil || err.Error() != "ADD COLUMN IF NOT EXISTS is only available for PostgreSQL" {
+			t.Fatal("should throw an error, it didn't:", err)
+		}
+
+		_, _, err = bob.AlterTable("users").Dialect(bob.PostgreSQL).TextColumn("email").First().ToSql()
+		if err == nil || err.Error() != "column positioning is only available for MySQL" {
+			t.Fatal("should throw an error, it didn't:", err)
+		}
+	})
+}
+
+func TestAlterTable(t *testing.T) {
+	t.Run("MySQL", func(t *testing.T) {
+		sql, _, err := bob.
+			AlterTable("users").
+			Dialect(bob.MySQL).
+			StringColumn("email", "NOT NULL").After("id").
+			AlterColumn("age").SetType("BIGINT").SetNotNull().
+			DropColumn("nickname").
+			RenameColumn("name", "full_name").
+			AddUnique("uq_users_email").Columns("email").
+			DropConstraint("fk_users_team").
+			ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected := "ALTER TABLE \"users\" ADD COLUMN \"email\" VARCHAR(255) NOT NULL AFTER \"id\", MODIFY COLUMN \"age\" BIGINT NOT NULL, DROP COLUMN \"nickname\", RENAME COLUMN \"name\" TO \"full_name\", ADD CONSTRAINT uq_users_email UNIQUE (\"email\"), DROP CONSTRAINT fk_users_team"
+		if sql != expected {
+			t.Fatalf("Expected %s, got %s", expected, sql)
+		}
+	})
+
+	t.Run("PostgreSQL", func(t *testing.T) {
+		statements, err := bob.
+			AlterTable("posts").
+			Dialect(bob.PostgreSQL).
+			TextColumn("slug").
+			AddForeignKey("fk_posts_user").Columns("user_id").References("users", "id").NotValid().
+			RenameColumn("body", "content").
+			DropColumn("draft").
+			ToStatements()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected := []bob.Statement{
+			{SQL: "ALTER TABLE \"posts\" ADD COLUMN \"slug\" TEXT, ADD CONSTRAINT fk_posts_user FOREIGN KEY (\"user_id\") REFERENCES users (\"id\") NOT VALID"},
+			{SQL: "ALTER TABLE \"posts\" RENAME COLUMN \"body\" TO \"content\""},
+			{SQL: "ALTER TABLE \"posts\" DROP COLUMN \"draft\""},
+			{SQL: "ALTER TABLE \"posts\" VALIDATE CONSTRAINT fk_posts_user"},
+		}
+		if !reflect.DeepEqual(statements, expected) {
+			t.Fatalf("Expected %v, got %v", expected, statements)
+		}
+	})
+
+	t.Run("SQLite", func(t *testing.T) {
+		sql, _, err := bob.
+			AlterTable("users").
+			Dialect(bob.SQLite).
+			TextColumn("email").
+			RenameColumn("name", "full_name").
+			ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected := "ALTER TABLE \"users\" ADD COLUMN \"email\" TEXT; ALTER TABLE \"users\" RENAME COLUMN \"name\" TO \"full_name\";"
+		if sql != expected {
+			t.Fatalf("Expected %s, got %s", expected, sql)
+		}
+	})
+
+	t.Run("MSSQL", func(t *testing.T) {
+		statements, err := bob.
+			AlterTable("users").
+			Dialect(bob.MSSQL).
+			IntColumn("age").
+			TextColumn("bio").
+			DropColumn("nickname").
+			DropColumn("avatar").
+			AddUnique("uq_users_email").Columns("email").
+			ToStatements()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected := []bob.Statement{
+			{SQL: "ALTER TABLE \"users\" ADD \"age\" INT, \"bio\" TEXT"},
+			{SQL: "ALTER TABLE \"users\" DROP COLUMN \"nickname\", \"avatar\""},
+			{SQL: "ALTER TABLE \"users\" ADD CONSTRAINT uq_users_email UNIQUE (\"email\")"},
+		}
+		if !reflect.DeepEqual(statements, expected) {
+			t.Fatalf("Expected %v, got %v", expected, statements)
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		cases := []struct {
+			builder bob.AlterBuilder
+			err     string
+		}{
+			{bob.AlterTable("users"), "alter statement must have at least one action"},
+			{bob.AlterTable("users").SetNotNull(), "an action must be specified before its options"},
+			{bob.AlterTable("users").DropColumn("name").First(), "First, After and IfNotExists must follow an added column"},
+			{bob.AlterTable("users").DropColumn("name").SetType("TEXT"), "column changes must follow AlterColumn"},
+			{bob.AlterTable("users").DropColumn("name").Columns("email"), "constraint options must follow an added constraint"},
+		}
+
+		for _, c := range cases {
+			_, _, err := c.builder.ToSql()
+			if err == nil || err.Error() != c.err {
+				t.Errorf("Expected error: %s. Got: %v", c.err, err)
+			}
+		}
+	})
+}
+
+func TestAlterSchema(t *testing.T) {
+	t.Run("WithSchema", func(t *testing.T) {
+		sql, _, err := bob.DropColumn("users", "nickname").WithSchema("tenant_1").ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected := "ALTER TABLE \"tenant_1\".\"users\" DROP COLUMN \"nickname\""
+		if sql != expected {
+			t.Fatalf("Expected %s, got %s", expected, sql)
+		}
+	})
+
+	t.Run("SetSchema", func(t *testing.T) {
+		cases := []struct {
+			dialect    int
+			statements []bob.Statement
+		}{
+			{bob.PostgreSQL, []bob.Statement{
+				{SQL: "ALTER TABLE \"tenant_1\".\"users\" DROP COLUMN \"nickname\""},
+				{SQL: "ALTER TABLE \"tenant_1\".\"users\" SET SCHEMA \"archive\""},
+			}},
+			{bob.MySQL, []bob.Statement{
+				{SQL: "ALTER TABLE \"tenant_1\".\"users\" DROP COLUMN \"nickname\""},
+				{SQL: "RENAME TABLE \"tenant_1\".\"users\" TO \"archive\".\"users\";"},
+			}},
+			{bob.MSSQL, []bob.Statement{
+				{SQL: "ALTER TABLE \"tenant_1\".\"users\" DROP COLUMN \"nickname\""},
+				{SQL: "ALTER SCHEMA \"archive\" TRANSFER \"tenant_1\".\"users\";"},
+			}},
+		}
+
+		for _, c := range cases {
+			statements, err := bob.
+				AlterTable("users").
+				WithSchema("tenant_1").
+				Dialect(c.dialect).
+				SetSchema("archive").
+				DropColumn("nickname").
+				ToStatements()
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+
+			if !reflect.DeepEqual(statements, c.statements) {
+				t.Errorf("Expected %v, got %v", c.statements, statements)
+			}
+		}
+	})
+
+	t.Run("SetSchema on SQLite", func(t *testing.T) {
+		_, _, err := bob.AlterTable("users").Dialect(bob.SQLite).SetSchema("archive").ToSql()
+		if err == nil || err.Error() != "SQLite doesn't support moving a table into another schema" {
+			t.Fatal("should throw an error, it didn't:", err)
+		}
+	})
+}
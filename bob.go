@@ -73,6 +73,16 @@ func (b BobBuilderType) CreateIndexIfNotExists(name string) IndexBuilder {
 	return IndexBuilder(b).name(name).ifNotExists()
 }
 
+// DropIndex drops an index with DropIndexBuilder interface.
+func (b BobBuilderType) DropIndex(name string) DropIndexBuilder {
+	return DropIndexBuilder(b).name(name)
+}
+
+// RenameIndex renames an existing index of a table.
+func (b BobBuilderType) RenameIndex(table, from, to string) RenameIndexBuilder {
+	return RenameIndexBuilder(b).rename(table, from, to)
+}
+
 // HasTable checks if a table exists with HasBuilder interface
 func (b BobBuilderType) HasTable(table string) HasBuilder {
 	return HasBuilder(b).HasTable(table)
@@ -83,14 +93,45 @@ func (b BobBuilderType) HasColumn(column string) HasBuilder {
 	return HasBuilder(b).HasColumn(column)
 }
 
-// DropTable drops (delete contents & remove) a table from the database.
-func (b BobBuilderType) DropTable(table string) DropBuilder {
-	return DropBuilder(b).dropTable(table)
+// DropTable drops (delete contents & remove) one or more tables from the database.
+func (b BobBuilderType) DropTable(tables ...string) DropBuilder {
+	return DropBuilder(b).drop("TABLE", tables)
 }
 
-// DropTable drops (delete contents & remove) a table from the database if the table exists.
-func (b BobBuilderType) DropTableIfExists(table string) DropBuilder {
-	return DropBuilder(b).dropTable(table).ifExists()
+// DropTable drops (delete contents & remove) one or more tables from the database if the tables exist.
+func (b BobBuilderType) DropTableIfExists(tables ...string) DropBuilder {
+	return DropBuilder(b).drop("TABLE", tables).IfExists()
+}
+
+// DropView drops one or more views from the database.
+func (b BobBuilderType) DropView(views ...string) DropBuilder {
+	return DropBuilder(b).drop("VIEW", views)
+}
+
+// DropMaterializedView drops one or more materialized views from the database. Only available for PostgreSQL.
+func (b BobBuilderType) DropMaterializedView(views ...string) DropBuilder {
+	return DropBuilder(b).drop("MATERIALIZED VIEW", views)
+}
+
+// DropSchema drops one or more schemas from the database.
+func (b BobBuilderType) DropSchema(schemas ...string) DropBuilder {
+	return DropBuilder(b).drop("SCHEMA", schemas)
+}
+
+// DropSequence drops one or more sequences from the database.
+func (b BobBuilderType) DropSequence(sequences ...string) DropBuilder {
+	return DropBuilder(b).drop("SEQUENCE", sequences)
+}
+
+// DropType drops one or more user-defined types from the database.
+func (b BobBuilderType) DropType(types ...string) DropBuilder {
+	return DropBuilder(b).drop("TYPE", types)
+}
+
+// DropFunction drops one or more functions from the database.
+// The names are not quoted, so the argument types can be given along with the name.
+func (b BobBuilderType) DropFunction(functions ...string) DropBuilder {
+	return DropBuilder(b).drop("FUNCTION", functions)
 }
 
 // RenameTable simply renames an exisisting table.
@@ -98,9 +139,9 @@ func (b BobBuilderType) RenameTable(from, to string) RenameBuilder {
 	return RenameBuilder(b).from(from).to(to)
 }
 
-// Truncate performs TRUNCATE function. It deletes all contents from a table but not deleting the table.
-func (b BobBuilderType) Truncate(table string) TruncateBuilder {
-	return TruncateBuilder(b).truncate(table)
+// Truncate performs TRUNCATE function. It deletes all contents from one or more tables but not deleting the tables.
+func (b BobBuilderType) Truncate(tables ...string) TruncateBuilder {
+	return TruncateBuilder(b).truncate(tables)
 }
 
 // Upsert upserts a row into a table.
@@ -108,24 +149,64 @@ func (b BobBuilderType) Upsert(table string, dialect int) UpsertBuilder {
 	return UpsertBuilder(b).dialect(dialect).into(table)
 }
 
-// DropTable drops (delete contents & remove) a table from the database if the table exists.
+// AlterTable alters an existing table with AlterBuilder interface.
+func (b BobBuilderType) AlterTable(table string) AlterBuilder {
+	return AlterBuilder(b).tableName(table)
+}
+
+// AddColumn adds a column into an existing table.
+func (b BobBuilderType) AddColumn(table string, column ColumnDef) AlterBuilder {
+	return AlterBuilder(b).tableName(table).AddColumn(column)
+}
+
+// AlterColumn changes the type, default value or nullability of an existing column.
+func (b BobBuilderType) AlterColumn(table, column string) AlterBuilder {
+	return AlterBuilder(b).tableName(table).AlterColumn(column)
+}
+
+// AddForeignKey adds a foreign key constraint into an existing table.
+func (b BobBuilderType) AddForeignKey(table, name string) AlterBuilder {
+	return AlterBuilder(b).tableName(table).AddForeignKey(name)
+}
+
+// AddUnique adds a unique constraint into an existing table.
+func (b BobBuilderType) AddUnique(table, name string) AlterBuilder {
+	return AlterBuilder(b).tableName(table).AddUnique(name)
+}
+
+// AddCheck adds a check constraint into an existing table.
+func (b BobBuilderType) AddCheck(table, name, expr string) AlterBuilder {
+	return AlterBuilder(b).tableName(table).AddCheck(name, expr)
+}
+
+// AddPrimaryKey adds a primary key constraint into an existing table.
+func (b BobBuilderType) AddPrimaryKey(table, name string) AlterBuilder {
+	return AlterBuilder(b).tableName(table).AddPrimaryKey(name)
+}
+
+// ValidateConstraint validates a constraint that was added without validation.
+func (b BobBuilderType) ValidateConstraint(table, name string) AlterBuilder {
+	return AlterBuilder(b).tableName(table).ValidateConstraint(name)
+}
+
+// DropColumn drops (delete contents & remove) a column from the table.
 func (b BobBuilderType) DropColumn(table, column string) AlterBuilder {
-	return AlterBuilder(b).whatToAlter(alterDropColumn).tableName(table).firstKey(column)
+	return AlterBuilder(b).tableName(table).DropColumn(column)
 }
 
 // DropConstraint drops (delete contents & remove) a constraint from the database.
 func (b BobBuilderType) DropConstraint(table, constraint string) AlterBuilder {
-	return AlterBuilder(b).whatToAlter(alterDropConstraint).tableName(table).firstKey(constraint)
+	return AlterBuilder(b).tableName(table).DropConstraint(constraint)
 }
 
 // RenameColumn simply renames an exisisting column.
 func (b BobBuilderType) RenameColumn(table, from, to string) AlterBuilder {
-	return AlterBuilder(b).whatToAlter(alterRenameColumn).tableName(table).firstKey(from).secondKey(to)
+	return AlterBuilder(b).tableName(table).RenameColumn(from, to)
 }
 
 // RenameConstraint simply renames an exisisting constraint.
 func (b BobBuilderType) RenameConstraint(table, from, to string) AlterBuilder {
-	return AlterBuilder(b).whatToAlter(alterRenameConstraint).tableName(table).firstKey(from).secondKey(to)
+	return AlterBuilder(b).tableName(table).RenameConstraint(from, to)
 }
 
 // BobStmtBuilder is the parent builder for BobBuilderType
@@ -152,14 +233,45 @@ func HasColumn(col string) HasBuilder {
 	return BobStmtBuilder.HasColumn(col)
 }
 
-// DropTable drops (delete contents & remove) a table from the database.
-func DropTable(table string) DropBuilder {
-	return BobStmtBuilder.DropTable(table)
+// DropTable drops (delete contents & remove) one or more tables from the database.
+func DropTable(tables ...string) DropBuilder {
+	return BobStmtBuilder.DropTable(tables...)
 }
 
-// DropTable drops (delete contents & remove) a table from the database if the table exists.
-func DropTableIfExists(table string) DropBuilder {
-	return BobStmtBuilder.DropTableIfExists(table)
+// DropTable drops (delete contents & remove) one or more tables from the database if the tables exist.
+func DropTableIfExists(tables ...string) DropBuilder {
+	return BobStmtBuilder.DropTableIfExists(tables...)
+}
+
+// DropView drops one or more views from the database.
+func DropView(views ...string) DropBuilder {
+	return BobStmtBuilder.DropView(views...)
+}
+
+// DropMaterializedView drops one or more materialized views from the database. Only available for PostgreSQL.
+func DropMaterializedView(views ...string) DropBuilder {
+	return BobStmtBuilder.DropMaterializedView(views...)
+}
+
+// DropSchema drops one or more schemas from the database.
+func DropSchema(schemas ...string) DropBuilder {
+	return BobStmtBuilder.DropSchema(schemas...)
+}
+
+// DropSequence drops one or more sequences from the database.
+func DropSequence(sequences ...string) DropBuilder {
+	return BobStmtBuilder.DropSequence(sequences...)
+}
+
+// DropType drops one or more user-defined types from the database.
+func DropType(types ...string) DropBuilder {
+	return BobStmtBuilder.DropType(types...)
+}
+
+// DropFunction drops one or more functions from the database.
+// The names are not quoted, so the argument types can be given along with the name.
+func DropFunction(functions ...string) DropBuilder {
+	return BobStmtBuilder.DropFunction(functions...)
 }
 
 // RenameTable simply renames an exisisting table.
@@ -167,9 +279,9 @@ func RenameTable(from, to string) RenameBuilder {
 	return BobStmtBuilder.RenameTable(from, to)
 }
 
-// Truncate performs TRUNCATE function. It deletes all contents from a table but not deleting the table.
-func Truncate(table string) TruncateBuilder {
-	return BobStmtBuilder.Truncate(table)
+// Truncate performs TRUNCATE function. It deletes all contents from one or more tables but not deleting the tables.
+func Truncate(tables ...string) TruncateBuilder {
+	return BobStmtBuilder.Truncate(tables...)
 }
 
 // Upsert performs a UPSERT query with specified database dialect.
@@ -188,6 +300,64 @@ func CreateIndexIfNotExists(name string) IndexBuilder {
 	return BobStmtBuilder.CreateIndexIfNotExists(name)
 }
 
+// DropIndex drops an index with DropIndexBuilder interface.
+func DropIndex(name string) DropIndexBuilder {
+	return BobStmtBuilder.DropIndex(name)
+}
+
+// RenameIndex renames an existing index of a table.
+func RenameIndex(table, from, to string) RenameIndexBuilder {
+	return BobStmtBuilder.RenameIndex(table, from, to)
+}
+
+// AlterTable alters an existing table with AlterBuilder interface.
+// Multiple actions can be chained, they are rendered into a single statement
+// where the dialect allows it, and into multiple statements otherwise.
+func AlterTable(table string) AlterBuilder {
+	return BobStmtBuilder.AlterTable(table)
+}
+
+// AddColumn adds a column into an existing table.
+// It accepts the same column definition as CreateBuilder.AddColumn.
+func AddColumn(table string, column ColumnDef) AlterBuilder {
+	return BobStmtBuilder.AddColumn(table, column)
+}
+
+// AlterColumn changes the type, default value or nullability of an existing column.
+// Chain it with SetType, Using, SetDefault, DropDefault, SetNotNull or DropNotNull.
+func AlterColumn(table, column string) AlterBuilder {
+	return BobStmtBuilder.AlterColumn(table, column)
+}
+
+// AddForeignKey adds a foreign key constraint into an existing table.
+// Chain it with Columns, References, OnDelete and OnUpdate.
+func AddForeignKey(table, name string) AlterBuilder {
+	return BobStmtBuilder.AddForeignKey(table, name)
+}
+
+// AddUnique adds a unique constraint into an existing table.
+// Chain it with Columns.
+func AddUnique(table, name string) AlterBuilder {
+	return BobStmtBuilder.AddUnique(table, name)
+}
+
+// AddCheck adds a check constraint into an existing table.
+func AddCheck(table, name, expr string) AlterBuilder {
+	return BobStmtBuilder.AddCheck(table, name, expr)
+}
+
+// AddPrimaryKey adds a primary key constraint into an existing table.
+// Chain it with Columns.
+func AddPrimaryKey(table, name string) AlterBuilder {
+	return BobStmtBuilder.AddPrimaryKey(table, name)
+}
+
+// ValidateConstraint validates a constraint that was added with NotValid.
+// Only available for PostgreSQL and MSSQL.
+func ValidateConstraint(table, name string) AlterBuilder {
+	return BobStmtBuilder.ValidateConstraint(table, name)
+}
+
 // DropColumn drops (delete contents & remove) a column from the table.
 func DropColumn(table, column string) AlterBuilder {
 	return BobStmtBuilder.DropColumn(table, column)
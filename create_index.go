@@ -15,8 +15,12 @@ type indexData struct {
 	Fulltext    bool
 	Name        string
 	TableName   string
+	Schema      string
 	Columns     []IndexColumn
 	IfNotExists bool
+	Algorithm   DDLAlgorithm
+	Lock        DDLLock
+	Strict      bool
 }
 
 type IndexColumn struct {
@@ -53,6 +57,11 @@ func (i IndexBuilder) On(table string) IndexBuilder {
 	return builder.Set(i, "TableName", table).(IndexBuilder)
 }
 
+// WithSchema specifies the schema of the table the index is created on.
+func (i IndexBuilder) WithSchema(schema string) IndexBuilder {
+	return builder.Set(i, "Schema", schema).(IndexBuilder)
+}
+
 func (i IndexBuilder) Columns(column IndexColumn) IndexBuilder {
 	return builder.Append(i, "Columns", column).(IndexBuilder)
 }
@@ -62,6 +71,11 @@ func (i IndexBuilder) ToSql() (string, []interface{}, error) {
 	return data.ToSql()
 }
 
+// ToStatements returns the query as a list of statements to be executed one by one.
+func (i IndexBuilder) ToStatements() ([]Statement, error) {
+	return singleStatement(i)
+}
+
 func (i *indexData) ToSql() (sqlStr string, args []interface{}, err error) {
 	if i.Name == "" {
 		err = errors.New("index name is required on create index statement")
@@ -103,7 +117,7 @@ func (i *indexData) ToSql() (sqlStr string, args []interface{}, err error) {
 
 	sql.WriteString("ON ")
 
-	sql.WriteString(i.TableName + " ")
+	sql.WriteString(qualifiedName(i.Schema, i.TableName) + " ")
 
 	var columns []string
 	for _, column := range i.Columns {
@@ -120,7 +134,17 @@ func (i *indexData) ToSql() (sqlStr string, args []interface{}, err error) {
 
 	sql.WriteString("(")
 	sql.WriteString(strings.Join(columns, ", "))
-	sql.WriteString(");")
+	sql.WriteString(")")
+
+	options, err := i.onlineDDL()
+	if err != nil {
+		return
+	}
+	for _, option := range options {
+		sql.WriteString(" " + option)
+	}
+
+	sql.WriteString(";")
 
 	sqlStr = sql.String()
 	return
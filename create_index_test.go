@@ -19,7 +19,7 @@ func TestCreateIndex(t *testing.T) {
 		t.Fatal(err.Error())
 	}
 
-	result := "CREATE UNIQUE FULLTEXT SPATIAL INDEX IF NOT EXISTS email_idx ON users (email);"
+	result := "CREATE UNIQUE FULLTEXT SPATIAL INDEX IF NOT EXISTS email_idx ON \"users\" (email);"
 	if sql != result {
 		t.Fatal("sql is not equal to result:", sql)
 	}
@@ -36,7 +36,7 @@ func TestCreateIndex_Simple(t *testing.T) {
 		t.Fatal(err.Error())
 	}
 
-	result := "CREATE INDEX idx_email ON users (email COLLATE DEFAULT ASC, name DESC);"
+	result := "CREATE INDEX idx_email ON \"users\" (email COLLATE DEFAULT ASC, name DESC);"
 	if sql != result {
 		t.Fatal("sql is not equal to result:", sql)
 	}
@@ -89,3 +89,20 @@ func TestCreateIndex_Error(t *testing.T) {
 		}
 	})
 }
+
+func TestCreateIndex_WithSchema(t *testing.T) {
+	sql, _, err := bob.
+		CreateIndex("idx_email").
+		On("users").
+		WithSchema("tenant_1").
+		Columns(bob.IndexColumn{Name: "email"}).
+		ToSql()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	result := "CREATE INDEX idx_email ON \"tenant_1\".\"users\" (email);"
+	if sql != result {
+		t.Fatal("sql is not equal to result:", sql)
+	}
+}
@@ -14,6 +14,11 @@ type createData struct {
 	IfNotExists bool
 	Schema      string
 	Columns     []ColumnDef
+	PrimaryKey  []string
+	Constraints []createConstraint
+	Indexes     []createIndex
+	Dialect     int
+	HasDialect  bool
 }
 
 type ColumnDef struct {
@@ -22,6 +27,28 @@ type ColumnDef struct {
 	Extras []string
 }
 
+// render returns the column definition as it is written in CREATE TABLE and ALTER TABLE statements.
+func (c ColumnDef) render() string {
+	column := []string{"\"" + c.Name + "\" " + c.Type}
+	if len(c.Extras) > 0 {
+		column = append(column, strings.Join(c.Extras, " "))
+	}
+	return strings.Join(column, " ")
+}
+
+// createConstraint is a named table constraint.
+type createConstraint struct {
+	Name       string
+	Definition string
+}
+
+// createIndex is an index that is created along with the table.
+type createIndex struct {
+	Name    string
+	Unique  bool
+	Columns []string
+}
+
 func init() {
 	builder.Register(CreateBuilder{}, createData{})
 }
@@ -41,6 +68,15 @@ func (b CreateBuilder) WithSchema(name string) CreateBuilder {
 	return builder.Set(b, "Schema", name).(CreateBuilder)
 }
 
+// Dialect specifies the database dialect the statement is rendered for.
+func (b CreateBuilder) Dialect(db int) CreateBuilder {
+	return builder.Set(b, "Dialect", db).(CreateBuilder).hasDialect()
+}
+
+func (b CreateBuilder) hasDialect() CreateBuilder {
+	return builder.Set(b, "HasDialect", true).(CreateBuilder)
+}
+
 // StringColumn creates a column with VARCHAR(255) data type.
 // For SQLite please refer to TextColumn.
 func (b CreateBuilder) StringColumn(name string, extras ...string) CreateBuilder {
@@ -186,14 +222,55 @@ func (b CreateBuilder) AddColumn(column ColumnDef) CreateBuilder {
 	return builder.Append(b, "Columns", column).(CreateBuilder)
 }
 
+// PrimaryKey adds a table level PRIMARY KEY constraint spanning the given columns.
+// Use it for composite primary keys, otherwise put "PRIMARY KEY" on the column's extras.
+func (b CreateBuilder) PrimaryKey(columns ...string) CreateBuilder {
+	return builder.Extend(b, "PrimaryKey", columns).(CreateBuilder)
+}
+
+// Constraint adds a named table constraint, the definition is written as is.
+// For example: Constraint("fk_posts_user", "FOREIGN KEY (\"user_id\") REFERENCES users (\"id\")")
+func (b CreateBuilder) Constraint(name, definition string) CreateBuilder {
+	return builder.Append(b, "Constraints", createConstraint{Name: name, Definition: definition}).(CreateBuilder)
+}
+
+// Index adds an index to be created along with the table.
+// On MySQL it is rendered inline as an INDEX clause, other dialects
+// get a separate CREATE INDEX statement after the CREATE TABLE statement.
+func (b CreateBuilder) Index(name string, columns ...string) CreateBuilder {
+	return builder.Append(b, "Indexes", createIndex{Name: name, Columns: columns}).(CreateBuilder)
+}
+
+// UniqueIndex is the same as Index, but creates a UNIQUE index.
+func (b CreateBuilder) UniqueIndex(name string, columns ...string) CreateBuilder {
+	return builder.Append(b, "Indexes", createIndex{Name: name, Unique: true, Columns: columns}).(CreateBuilder)
+}
+
 // ToSql returns 3 variables filled out with the correct values based on bindings, etc.
 func (b CreateBuilder) ToSql() (string, []interface{}, error) {
 	data := builder.GetStruct(b).(createData)
 	return data.ToSql()
 }
 
+// ToStatements returns every statement needed to create the table and its indexes.
+func (b CreateBuilder) ToStatements() ([]Statement, error) {
+	data := builder.GetStruct(b).(createData)
+	return data.ToStatements()
+}
+
 // ToSql returns 3 variables filled out with the correct values based on bindings, etc.
 func (d *createData) ToSql() (sqlStr string, args []interface{}, err error) {
+	statements, err := d.ToStatements()
+	if err != nil {
+		return
+	}
+
+	sqlStr, args = joinStatements(statements)
+	return
+}
+
+// ToStatements returns every statement needed to create the table and its indexes.
+func (d *createData) ToStatements() (statements []Statement, err error) {
 	if len(d.TableName) == 0 || d.TableName == "" {
 		err = errors.New("create statements must specify a table")
 		return
@@ -204,6 +281,20 @@ func (d *createData) ToSql() (sqlStr string, args []interface{}, err error) {
 		return
 	}
 
+	if d.HasDialect && !isDialectSupported(d.Dialect) {
+		err = ErrDialectNotSupported
+		return
+	}
+
+	for _, index := range d.Indexes {
+		if index.Name == "" || len(index.Columns) == 0 {
+			err = errors.New("an index must have a name and at least one column")
+			return
+		}
+	}
+
+	inlineIndexes := d.HasDialect && d.Dialect == MySQL
+
 	var sql strings.Builder
 
 	sql.WriteString("CREATE TABLE ")
@@ -212,27 +303,74 @@ func (d *createData) ToSql() (sqlStr string, args []interface{}, err error) {
 		sql.WriteString("IF NOT EXISTS ")
 	}
 
-	if d.Schema != "" {
-		sql.WriteString("\"" + d.Schema + "\".")
-	}
+	tableName := qualifiedName(d.Schema, d.TableName)
 
-	sql.WriteString("\"" + d.TableName + "\"")
+	sql.WriteString(tableName)
 	sql.WriteString(" ")
 
 	var columnTypes []string
-	for i := 0; i < len(d.Columns); i++ {
-		var column []string
-		column = append(column, "\""+d.Columns[i].Name+"\" "+d.Columns[i].Type)
-		if len(d.Columns[i].Extras) > 0 {
-			column = append(column, strings.Join(d.Columns[i].Extras, " "))
+	for _, column := range d.Columns {
+		columnTypes = append(columnTypes, column.render())
+	}
+
+	if len(d.PrimaryKey) > 0 {
+		columnTypes = append(columnTypes, "PRIMARY KEY ("+quoteColumns(d.PrimaryKey)+")")
+	}
+
+	for _, constraint := range d.Constraints {
+		columnTypes = append(columnTypes, "CONSTRAINT \""+constraint.Name+"\" "+constraint.Definition)
+	}
+
+	if inlineIndexes {
+		for _, index := range d.Indexes {
+			var indexType string
+			if index.Unique {
+				indexType = "UNIQUE "
+			}
+			columnTypes = append(columnTypes, indexType+"INDEX \""+index.Name+"\" ("+quoteColumns(index.Columns)+")")
 		}
-		columnTypes = append(columnTypes, strings.Join(column, " "))
 	}
 
 	sql.WriteString("(")
 	sql.WriteString(strings.Join(columnTypes, ", "))
 	sql.WriteString(");")
 
-	sqlStr = sql.String()
+	statements = append(statements, Statement{SQL: sql.String()})
+
+	if inlineIndexes {
+		return
+	}
+
+	for _, index := range d.Indexes {
+		var columns []IndexColumn
+		for _, column := range index.Columns {
+			columns = append(columns, IndexColumn{Name: "\"" + column + "\""})
+		}
+
+		indexName := "\"" + index.Name + "\""
+		indexSchema := d.Schema
+		if d.HasDialect && d.Dialect == SQLite {
+			// SQLite qualifies the index name with the schema, not the table.
+			indexName = qualifiedName(d.Schema, index.Name)
+			indexSchema = ""
+		}
+
+		indexData := indexData{
+			Unique:      index.Unique,
+			Name:        indexName,
+			Schema:      indexSchema,
+			TableName:   d.TableName,
+			Columns:     columns,
+			IfNotExists: d.IfNotExists && !(d.HasDialect && d.Dialect == MSSQL),
+		}
+
+		var indexSql string
+		indexSql, _, err = indexData.ToSql()
+		if err != nil {
+			return
+		}
+		statements = append(statements, Statement{SQL: indexSql})
+	}
+
 	return
 }
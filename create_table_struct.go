@@ -0,0 +1,322 @@
+package bob
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// StructOptions configures how CreateTableFromStruct reads a Go struct.
+type StructOptions struct {
+	// Dialect decides which column types the Go types are mapped into.
+	Dialect int
+	// Schema is passed into CreateBuilder.WithSchema when not empty.
+	Schema string
+	// IfNotExists renders CREATE TABLE IF NOT EXISTS.
+	IfNotExists bool
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// nullTypes maps database/sql's Null* wrappers into the type they are holding.
+var nullTypes = map[reflect.Type]reflect.Type{
+	reflect.TypeOf(sql.NullString{}):  reflect.TypeOf(""),
+	reflect.TypeOf(sql.NullInt64{}):   reflect.TypeOf(int64(0)),
+	reflect.TypeOf(sql.NullInt32{}):   reflect.TypeOf(int32(0)),
+	reflect.TypeOf(sql.NullInt16{}):   reflect.TypeOf(int16(0)),
+	reflect.TypeOf(sql.NullByte{}):    reflect.TypeOf(byte(0)),
+	reflect.TypeOf(sql.NullFloat64{}): reflect.TypeOf(float64(0)),
+	reflect.TypeOf(sql.NullBool{}):    reflect.TypeOf(false),
+	reflect.TypeOf(sql.NullTime{}):    timeType,
+}
+
+// structField is a single column read from a struct field.
+type structField struct {
+	Name    string
+	Type    reflect.Type
+	Options []string
+	Index   []int
+}
+
+// CreateTableFromStruct creates a table with CreateBuilder interface from the fields of a struct.
+//
+// The column name is taken from the `db` tag, or the lowercased field name if there is none.
+// Column type and modifiers are read from the `bob` tag, for example:
+//
+//	Email string `db:"email" bob:"type:varchar(120),notnull,unique"`
+//
+// Available options are type:<type>, notnull, unique, primarykey, autoincrement and default:<value>.
+// A field tagged with `db:"-"` or `bob:"-"` is skipped. If the type option is not given, the column
+// type is derived from the Go type based on opts.Dialect.
+func CreateTableFromStruct(table string, v interface{}, opts StructOptions) (CreateBuilder, error) {
+	b := CreateTable(table)
+	if opts.IfNotExists {
+		b = CreateTableIfNotExists(table)
+	}
+	b = b.Dialect(opts.Dialect)
+	if opts.Schema != "" {
+		b = b.WithSchema(opts.Schema)
+	}
+
+	fields, err := structFields(v)
+	if err != nil {
+		return b, err
+	}
+
+	var primaryKeys []string
+	for _, field := range fields {
+		if hasOption(field.Options, "primarykey") {
+			primaryKeys = append(primaryKeys, field.Name)
+		}
+	}
+
+	for _, field := range fields {
+		column, err := columnFromField(field, opts.Dialect, len(primaryKeys) == 1)
+		if err != nil {
+			return b, err
+		}
+		b = b.AddColumn(column)
+	}
+
+	if len(primaryKeys) > 1 {
+		b = b.PrimaryKey(primaryKeys...)
+	}
+
+	return b, nil
+}
+
+// structFields walks over the exported fields of a struct, flattening embedded structs.
+func structFields(v interface{}) ([]structField, error) {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, errors.New("a struct or a pointer to a struct must be provided")
+	}
+
+	var fields []structField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+
+		name := field.Tag.Get("db")
+		tag := field.Tag.Get("bob")
+		if name == "-" || tag == "-" {
+			continue
+		}
+
+		fieldType := field.Type
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct && !isColumnType(fieldType) {
+			embedded, err := structFields(reflect.New(fieldType).Interface())
+			if err != nil {
+				return nil, err
+			}
+			for _, e := range embedded {
+				e.Index = append([]int{i}, e.Index...)
+				fields = append(fields, e)
+			}
+			continue
+		}
+
+		if field.PkgPath != "" {
+			continue
+		}
+
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+
+		fields = append(fields, structField{
+			Name:    name,
+			Type:    fieldType,
+			Options: splitTagOptions(tag),
+			Index:   field.Index,
+		})
+	}
+
+	return fields, nil
+}
+
+// isColumnType reports whether a struct type should be stored on a single column.
+func isColumnType(t reflect.Type) bool {
+	if t == timeType {
+		return true
+	}
+	_, ok := nullTypes[t]
+	return ok
+}
+
+// columnFromField converts a struct field into a ColumnDef.
+func columnFromField(field structField, dialect int, singlePrimaryKey bool) (ColumnDef, error) {
+	column := ColumnDef{Name: field.Name}
+
+	if columnType, ok := tagOption(field.Options, "type"); ok {
+		column.Type = columnType
+	} else {
+		columnType, err := columnTypeOf(field.Type, dialect)
+		if err == ErrDialectNotSupported {
+			return column, err
+		}
+		if err != nil {
+			return column, errors.New("field " + field.Name + ": " + err.Error())
+		}
+		column.Type = columnType
+	}
+
+	if hasOption(field.Options, "notnull") {
+		column.Extras = append(column.Extras, "NOT NULL")
+	}
+
+	if hasOption(field.Options, "unique") {
+		column.Extras = append(column.Extras, "UNIQUE")
+	}
+
+	if singlePrimaryKey && hasOption(field.Options, "primarykey") {
+		column.Extras = append(column.Extras, "PRIMARY KEY")
+	}
+
+	if hasOption(field.Options, "autoincrement") {
+		switch dialect {
+		case MySQL:
+			column.Extras = append(column.Extras, "AUTO_INCREMENT")
+		case PostgreSQL:
+			column.Extras = append(column.Extras, "GENERATED BY DEFAULT AS IDENTITY")
+		case SQLite:
+			// SQLite only allows AUTOINCREMENT on a single INTEGER PRIMARY KEY column.
+			if !singlePrimaryKey || !hasOption(field.Options, "primarykey") {
+				return column, errors.New("field " + field.Name + ": autoincrement must be used on the single primary key on SQLite")
+			}
+			column.Extras = append(column.Extras, "AUTOINCREMENT")
+		case MSSQL:
+			column.Extras = append(column.Extras, "IDENTITY(1,1)")
+		default:
+			return column, ErrDialectNotSupported
+		}
+	}
+
+	if value, ok := tagOption(field.Options, "default"); ok {
+		column.Extras = append(column.Extras, "DEFAULT "+value)
+	}
+
+	return column, nil
+}
+
+// columnTypeOf maps a Go type into a column type for the given dialect.
+func columnTypeOf(t reflect.Type, dialect int) (string, error) {
+	if !isDialectSupported(dialect) {
+		return "", ErrDialectNotSupported
+	}
+
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if inner, ok := nullTypes[t]; ok {
+		t = inner
+	}
+
+	switch t {
+	case timeType:
+		return pickType(dialect, "DATETIME", "TIMESTAMP", "DATETIME", "DATETIME2"), nil
+	case rawMessageType:
+		return pickType(dialect, "JSON", "JSONB", "TEXT", "NVARCHAR(MAX)"), nil
+	}
+
+	switch t.Kind() {
+	case reflect.Array:
+		// Types like uuid.UUID are declared as [16]byte.
+		if t.Len() == 16 && t.Elem().Kind() == reflect.Uint8 {
+			return pickType(dialect, "BINARY(16)", "UUID", "BLOB", "UNIQUEIDENTIFIER"), nil
+		}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return pickType(dialect, "BLOB", "BYTEA", "BLOB", "VARBINARY(MAX)"), nil
+		}
+	case reflect.String:
+		return pickType(dialect, "VARCHAR(255)", "VARCHAR(255)", "TEXT", "NVARCHAR(255)"), nil
+	case reflect.Bool:
+		return pickType(dialect, "BOOLEAN", "BOOLEAN", "BOOLEAN", "BIT"), nil
+	case reflect.Int8, reflect.Uint8:
+		return pickType(dialect, "TINYINT", "SMALLINT", "INTEGER", "SMALLINT"), nil
+	case reflect.Int16, reflect.Uint16:
+		return pickType(dialect, "SMALLINT", "SMALLINT", "INTEGER", "SMALLINT"), nil
+	case reflect.Int32, reflect.Uint32:
+		return pickType(dialect, "INT", "INTEGER", "INTEGER", "INT"), nil
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return pickType(dialect, "BIGINT", "BIGINT", "INTEGER", "BIGINT"), nil
+	case reflect.Float32:
+		return pickType(dialect, "FLOAT", "REAL", "REAL", "REAL"), nil
+	case reflect.Float64:
+		return pickType(dialect, "DOUBLE", "DOUBLE PRECISION", "REAL", "FLOAT"), nil
+	}
+
+	return "", errors.New("unsupported Go type " + t.String() + ", please specify the column type on the bob tag")
+}
+
+// pickType returns one of the column types based on the dialect.
+func pickType(dialect int, mysql, postgres, sqlite, mssql string) string {
+	switch dialect {
+	case PostgreSQL:
+		return postgres
+	case SQLite:
+		return sqlite
+	case MSSQL:
+		return mssql
+	default:
+		return mysql
+	}
+}
+
+// splitTagOptions splits a bob tag on commas, ignoring the ones inside parentheses
+// so that types such as decimal(10,2) are kept intact.
+func splitTagOptions(tag string) []string {
+	var options []string
+	var depth, start int
+	for i, r := range tag {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				options = append(options, strings.TrimSpace(tag[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	if last := strings.TrimSpace(tag[start:]); last != "" {
+		options = append(options, last)
+	}
+	return options
+}
+
+// hasOption checks if a flag option exists on the tag options.
+func hasOption(options []string, name string) bool {
+	for _, option := range options {
+		if strings.EqualFold(option, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// tagOption returns the value of a key:value option on the tag options.
+func tagOption(options []string, key string) (string, bool) {
+	for _, option := range options {
+		parts := strings.SplitN(option, ":", 2)
+		if len(parts) == 2 && strings.EqualFold(strings.TrimSpace(parts[0]), key) {
+			return strings.TrimSpace(parts[1]), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,167 @@
+package bob_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aldy505/bob"
+)
+
+type uuid [16]byte
+
+type timestamps struct {
+	CreatedAt time.Time    `db:"created_at" bob:"notnull,default:CURRENT_TIMESTAMP"`
+	DeletedAt sql.NullTime `db:"deleted_at"`
+}
+
+type user struct {
+	ID       int64          `db:"id" bob:"primarykey,autoincrement"`
+	Email    string         `db:"email" bob:"type:varchar(120),notnull,unique"`
+	Nickname sql.NullString `db:"nickname"`
+	Token    uuid           `db:"token"`
+	Settings json.RawMessage
+	Balance  float64 `bob:"type:decimal(10,2)"`
+	Ignored  string  `db:"-"`
+	password string
+	timestamps
+}
+
+func TestCreateTableFromStruct(t *testing.T) {
+	t.Run("PostgreSQL", func(t *testing.T) {
+		b, err := bob.CreateTableFromStruct("users", user{}, bob.StructOptions{Dialect: bob.PostgreSQL})
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		sql, _, err := b.ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		result := "CREATE TABLE \"users\" (\"id\" BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY, \"email\" varchar(120) NOT NULL UNIQUE, \"nickname\" VARCHAR(255), \"token\" UUID, \"settings\" JSONB, \"balance\" decimal(10,2), \"created_at\" TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP, \"deleted_at\" TIMESTAMP);"
+		if sql != result {
+			t.Fatal("sql is not equal to result:", sql)
+		}
+	})
+
+	t.Run("MySQL", func(t *testing.T) {
+		b, err := bob.CreateTableFromStruct("users", &user{}, bob.StructOptions{Dialect: bob.MySQL, IfNotExists: true})
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		sql, _, err := b.ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		result := "CREATE TABLE IF NOT EXISTS \"users\" (\"id\" BIGINT PRIMARY KEY AUTO_INCREMENT, \"email\" varchar(120) NOT NULL UNIQUE, \"nickname\" VARCHAR(255), \"token\" BINARY(16), \"settings\" JSON, \"balance\" decimal(10,2), \"created_at\" DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP, \"deleted_at\" DATETIME);"
+		if sql != result {
+			t.Fatal("sql is not equal to result:", sql)
+		}
+	})
+
+	t.Run("SQLite", func(t *testing.T) {
+		b, err := bob.CreateTableFromStruct("users", user{}, bob.StructOptions{Dialect: bob.SQLite})
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		sql, _, err := b.ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		result := "CREATE TABLE \"users\" (\"id\" INTEGER PRIMARY KEY AUTOINCREMENT, \"email\" varchar(120) NOT NULL UNIQUE, \"nickname\" TEXT, \"token\" BLOB, \"settings\" TEXT, \"balance\" decimal(10,2), \"created_at\" DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP, \"deleted_at\" DATETIME);"
+		if sql != result {
+			t.Fatal("sql is not equal to result:", sql)
+		}
+	})
+
+	t.Run("MSSQL", func(t *testing.T) {
+		b, err := bob.CreateTableFromStruct("users", user{}, bob.StructOptions{Dialect: bob.MSSQL, Schema: "dbo"})
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		sql, _, err := b.ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		result := "CREATE TABLE \"dbo\".\"users\" (\"id\" BIGINT PRIMARY KEY IDENTITY(1,1), \"email\" varchar(120) NOT NULL UNIQUE, \"nickname\" NVARCHAR(255), \"token\" UNIQUEIDENTIFIER, \"settings\" NVARCHAR(MAX), \"balance\" decimal(10,2), \"created_at\" DATETIME2 NOT NULL DEFAULT CURRENT_TIMESTAMP, \"deleted_at\" DATETIME2);"
+		if sql != result {
+			t.Fatal("sql is not equal to result:", sql)
+		}
+	})
+
+	t.Run("should render composite primary keys as a table constraint", func(t *testing.T) {
+		type membership struct {
+			TenantID int32  `db:"tenant_id" bob:"primarykey"`
+			UserID   int32  `db:"user_id" bob:"primarykey"`
+			Role     string `db:"role"`
+		}
+
+		b, err := bob.CreateTableFromStruct("memberships", membership{}, bob.StructOptions{Dialect: bob.PostgreSQL})
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		sql, _, err := b.ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		result := "CREATE TABLE \"memberships\" (\"tenant_id\" INTEGER, \"user_id\" INTEGER, \"role\" VARCHAR(255), PRIMARY KEY (\"tenant_id\", \"user_id\"));"
+		if sql != result {
+			t.Fatal("sql is not equal to result:", sql)
+		}
+	})
+}
+
+func TestCreateTableFromStruct_Error(t *testing.T) {
+	t.Run("should emit error on non-struct value", func(t *testing.T) {
+		_, err := bob.CreateTableFromStruct("users", "users", bob.StructOptions{})
+		if err == nil || err.Error() != "a struct or a pointer to a struct must be provided" {
+			t.Fatal("should throw an error, it didn't:", err)
+		}
+	})
+
+	t.Run("should emit error on unsupported type", func(t *testing.T) {
+		type invalid struct {
+			Tags map[string]string `db:"tags"`
+		}
+
+		_, err := bob.CreateTableFromStruct("users", invalid{}, bob.StructOptions{Dialect: bob.PostgreSQL})
+		if err == nil || err.Error() != "field tags: unsupported Go type map[string]string, please specify the column type on the bob tag" {
+			t.Fatal("should throw an error, it didn't:", err)
+		}
+	})
+
+	t.Run("should emit error on autoincrement outside of a single primary key on SQLite", func(t *testing.T) {
+		type counter struct {
+			Sequence int64 `db:"sequence" bob:"autoincrement"`
+		}
+		type membership struct {
+			UserID int64 `db:"user_id" bob:"primarykey,autoincrement"`
+			TeamID int64 `db:"team_id" bob:"primarykey"`
+		}
+
+		for _, v := range []interface{}{counter{}, membership{}} {
+			_, err := bob.CreateTableFromStruct("users", v, bob.StructOptions{Dialect: bob.SQLite})
+			if err == nil || !strings.Contains(err.Error(), "autoincrement must be used on the single primary key on SQLite") {
+				t.Fatal("should throw an error, it didn't:", err)
+			}
+		}
+	})
+
+	t.Run("should emit error on unsupported dialect", func(t *testing.T) {
+		_, err := bob.CreateTableFromStruct("users", user{}, bob.StructOptions{Dialect: 100})
+		if err != bob.ErrDialectNotSupported {
+			t.Fatal("should throw an error, it didn't:", err)
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package bob_test
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/aldy505/bob"
@@ -100,3 +101,103 @@ func TestCreateTable_IfNotExists(t *testing.T) {
 		t.Fatal("sql is not equal to result: ", sql)
 	}
 }
+
+func TestCreateTable_Indexes(t *testing.T) {
+	t.Run("should render inline indexes on MySQL", func(t *testing.T) {
+		sql, _, err := bob.
+			CreateTable("users").
+			Dialect(bob.MySQL).
+			IntColumn("id", "PRIMARY KEY").
+			StringColumn("email").
+			StringColumn("name").
+			UniqueIndex("idx_email", "email").
+			Index("idx_name", "name", "id").
+			ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		result := "CREATE TABLE \"users\" (\"id\" INT PRIMARY KEY, \"email\" VARCHAR(255), \"name\" VARCHAR(255), UNIQUE INDEX \"idx_email\" (\"email\"), INDEX \"idx_name\" (\"name\", \"id\"));"
+		if sql != result {
+			t.Fatal("sql is not equal to result:", sql)
+		}
+	})
+
+	t.Run("should render companion statements on PostgreSQL", func(t *testing.T) {
+		statements, err := bob.
+			CreateTableIfNotExists("users").
+			Dialect(bob.PostgreSQL).
+			WithSchema("private").
+			UUIDColumn("id", "PRIMARY KEY").
+			StringColumn("email").
+			UniqueIndex("idx_email", "email").
+			ToStatements()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		result := []bob.Statement{
+			{SQL: "CREATE TABLE IF NOT EXISTS \"private\".\"users\" (\"id\" UUID PRIMARY KEY, \"email\" VARCHAR(255));"},
+			{SQL: "CREATE UNIQUE INDEX IF NOT EXISTS \"idx_email\" ON \"private\".\"users\" (\"email\");"},
+		}
+		if !reflect.DeepEqual(statements, result) {
+			t.Fatal("statements are not equal to result:", statements)
+		}
+	})
+
+	t.Run("should qualify the index name with the schema on SQLite", func(t *testing.T) {
+		statements, err := bob.
+			CreateTable("users").
+			Dialect(bob.SQLite).
+			WithSchema("private").
+			TextColumn("email").
+			Index("idx_email", "email").
+			ToStatements()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		result := []bob.Statement{
+			{SQL: "CREATE TABLE \"private\".\"users\" (\"email\" TEXT);"},
+			{SQL: "CREATE INDEX \"private\".\"idx_email\" ON \"users\" (\"email\");"},
+		}
+		if !reflect.DeepEqual(statements, result) {
+			t.Fatal("statements are not equal to result:", statements)
+		}
+	})
+
+	t.Run("should join companion statements on ToSql", func(t *testing.T) {
+		sql, _, err := bob.
+			CreateTableIfNotExists("users").
+			Dialect(bob.MSSQL).
+			IntColumn("id").
+			Index("idx_id", "id").
+			ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		result := "CREATE TABLE IF NOT EXISTS \"users\" (\"id\" INT); CREATE INDEX \"idx_id\" ON \"users\" (\"id\");"
+		if sql != result {
+			t.Fatal("sql is not equal to result:", sql)
+		}
+	})
+
+	t.Run("should emit error on index without columns", func(t *testing.T) {
+		_, _, err := bob.
+			CreateTable("users").
+			IntColumn("id").
+			Index("idx_id").
+			ToSql()
+		if err == nil || err.Error() != "an index must have a name and at least one column" {
+			t.Fatal("should throw an error, it didn't:", err)
+		}
+	})
+
+	t.Run("should emit error on unsupported dialect", func(t *testing.T) {
+		_, _, err := bob.
+			CreateTable("users").
+			Dialect(100).
+			IntColumn("id").
+			ToSql()
+		if err != bob.ErrDialectNotSupported {
+			t.Fatal("should throw an error, it didn't:", err)
+		}
+	})
+}
@@ -10,25 +10,87 @@ import (
 type DropBuilder builder.Builder
 
 type dropData struct {
-	TableName string
-	IfExists  bool
-	Cascade   bool
-	Restrict  bool
+	Object     string
+	Names      []string
+	Schema     string
+	IfExists   bool
+	Cascade    bool
+	Restrict   bool
+	Dialect    int
+	HasDialect bool
+}
+
+// dropObject describes which dialects are able to drop a kind of object.
+type dropObject struct {
+	// Dialects are the dialects that have the object.
+	Dialects []int
+	// Combined are the dialects that drop several objects in a single statement.
+	Combined []int
+	// Cascade are the dialects that accept CASCADE and RESTRICT.
+	Cascade []int
+	// Raw objects are rendered without quotes, so a function can be given with its arguments.
+	Raw bool
+}
+
+var dropObjects = map[string]dropObject{
+	"TABLE": {
+		Dialects: []int{MySQL, PostgreSQL, SQLite, MSSQL},
+		Combined: []int{MySQL, PostgreSQL, MSSQL},
+		Cascade:  []int{MySQL, PostgreSQL},
+	},
+	"VIEW": {
+		Dialects: []int{MySQL, PostgreSQL, SQLite, MSSQL},
+		Combined: []int{MySQL, PostgreSQL, MSSQL},
+		Cascade:  []int{MySQL, PostgreSQL},
+	},
+	"MATERIALIZED VIEW": {
+		Dialects: []int{PostgreSQL},
+		Combined: []int{PostgreSQL},
+		Cascade:  []int{PostgreSQL},
+	},
+	"SCHEMA": {
+		Dialects: []int{MySQL, PostgreSQL, MSSQL},
+		Combined: []int{PostgreSQL},
+		Cascade:  []int{PostgreSQL},
+	},
+	"SEQUENCE": {
+		Dialects: []int{PostgreSQL, MSSQL},
+		Combined: []int{PostgreSQL, MSSQL},
+		Cascade:  []int{PostgreSQL},
+	},
+	"TYPE": {
+		Dialects: []int{PostgreSQL, MSSQL},
+		Combined: []int{PostgreSQL},
+		Cascade:  []int{PostgreSQL},
+	},
+	"FUNCTION": {
+		Dialects: []int{MySQL, PostgreSQL, MSSQL},
+		Combined: []int{PostgreSQL, MSSQL},
+		Cascade:  []int{PostgreSQL},
+		Raw:      true,
+	},
 }
 
 func init() {
 	builder.Register(DropBuilder{}, dropData{})
 }
 
-// DropTable sets which table to be dropped
-func (b DropBuilder) dropTable(name string) DropBuilder {
-	return builder.Set(b, "TableName", name).(DropBuilder)
+// drop sets which objects to be dropped
+func (b DropBuilder) drop(object string, names []string) DropBuilder {
+	b = builder.Set(b, "Object", object).(DropBuilder)
+	return builder.Set(b, "Names", append([]string(nil), names...)).(DropBuilder)
 }
 
-func (b DropBuilder) ifExists() DropBuilder {
+// IfExists only drops the objects that exist.
+func (b DropBuilder) IfExists() DropBuilder {
 	return builder.Set(b, "IfExists", true).(DropBuilder)
 }
 
+// WithSchema specifies the schema of the dropped objects.
+func (b DropBuilder) WithSchema(schema string) DropBuilder {
+	return builder.Set(b, "Schema", schema).(DropBuilder)
+}
+
 func (b DropBuilder) Cascade() DropBuilder {
 	return builder.Set(b, "Cascade", true).(DropBuilder)
 }
@@ -37,26 +99,116 @@ func (b DropBuilder) Restrict() DropBuilder {
 	return builder.Set(b, "Restrict", true).(DropBuilder)
 }
 
+// Dialect specifies the database dialect the statement is rendered for.
+// Without a dialect, every object is dropped on a single statement.
+func (b DropBuilder) Dialect(db int) DropBuilder {
+	return builder.Set(b, "Dialect", db).(DropBuilder).hasDialect()
+}
+
+func (b DropBuilder) hasDialect() DropBuilder {
+	return builder.Set(b, "HasDialect", true).(DropBuilder)
+}
+
 // ToSql returns 3 variables filled out with the correct values based on bindings, etc.
 func (b DropBuilder) ToSql() (string, []interface{}, error) {
 	data := builder.GetStruct(b).(dropData)
 	return data.ToSql()
 }
 
+// ToStatements returns the query as a list of statements to be executed one by one.
+// Dialects that can't drop several objects at once get a statement for each object.
+func (b DropBuilder) ToStatements() ([]Statement, error) {
+	data := builder.GetStruct(b).(dropData)
+	return data.ToStatements()
+}
+
 // ToSql returns 3 variables filled out with the correct values based on bindings, etc.
 func (d *dropData) ToSql() (sqlStr string, args []interface{}, err error) {
-	if len(d.TableName) == 0 || d.TableName == "" {
-		err = errors.New("drop statement must specify a table")
+	statements, err := d.ToStatements()
+	if err != nil {
+		return
+	}
+
+	sqlStr, args = joinStatements(statements)
+	return
+}
+
+// ToStatements returns the query as a list of statements to be executed one by one.
+func (d *dropData) ToStatements() (statements []Statement, err error) {
+	object, ok := dropObjects[d.Object]
+	if !ok {
+		err = errors.New("unknown object to be dropped")
+		return
+	}
+
+	if len(d.Names) == 0 {
+		err = errors.New("drop statement must specify a " + strings.ToLower(d.Object))
+		return
+	}
+	for _, name := range d.Names {
+		if name == "" {
+			err = errors.New("drop statement must specify a " + strings.ToLower(d.Object))
+			return
+		}
+	}
+
+	if d.Object == "SCHEMA" && d.Schema != "" {
+		err = errors.New("WithSchema can't be used when dropping a schema")
+		return
+	}
+
+	combined := true
+	if d.HasDialect {
+		if !isDialectSupported(d.Dialect) {
+			err = ErrDialectNotSupported
+			return
+		}
+		if !containsDialect(object.Dialects, d.Dialect) {
+			err = errors.New("DROP " + d.Object + " is not available for this dialect")
+			return
+		}
+		if (d.Cascade || d.Restrict) && !containsDialect(object.Cascade, d.Dialect) {
+			err = errors.New("CASCADE and RESTRICT are not available for this dialect")
+			return
+		}
+		combined = containsDialect(object.Combined, d.Dialect)
+	}
+
+	var names []string
+	for _, name := range d.Names {
+		if object.Raw {
+			// Raw names, such as a function with its signature, can't be quoted.
+			if d.Schema != "" {
+				name = "\"" + d.Schema + "\"." + name
+			}
+			names = append(names, name)
+		} else {
+			names = append(names, qualifiedName(d.Schema, name))
+		}
+	}
+
+	if combined {
+		statements = append(statements, Statement{SQL: d.render(strings.Join(names, ", "))})
+		return
 	}
+
+	for _, name := range names {
+		statements = append(statements, Statement{SQL: d.render(name)})
+	}
+	return
+}
+
+// render renders a DROP statement of the given names.
+func (d *dropData) render(names string) string {
 	var sql strings.Builder
 
-	sql.WriteString("DROP TABLE ")
+	sql.WriteString("DROP " + d.Object + " ")
 
 	if d.IfExists {
 		sql.WriteString("IF EXISTS ")
 	}
 
-	sql.WriteString("\"" + d.TableName + "\"")
+	sql.WriteString(names)
 
 	if d.Cascade {
 		sql.WriteString(" CASCADE")
@@ -66,6 +218,15 @@ func (d *dropData) ToSql() (sqlStr string, args []interface{}, err error) {
 
 	sql.WriteString(";")
 
-	sqlStr = sql.String()
-	return
+	return sql.String()
+}
+
+// containsDialect checks if the dialect is one of the dialects.
+func containsDialect(dialects []int, dialect int) bool {
+	for _, d := range dialects {
+		if d == dialect {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,148 @@
+package bob
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/lann/builder"
+)
+
+type DropIndexBuilder builder.Builder
+
+type dropIndexData struct {
+	Name         string
+	TableName    string
+	Schema       string
+	IfExists     bool
+	Concurrently bool
+	Cascade      bool
+	Restrict     bool
+	Dialect      int
+	HasDialect   bool
+}
+
+func init() {
+	builder.Register(DropIndexBuilder{}, dropIndexData{})
+}
+
+func (b DropIndexBuilder) name(name string) DropIndexBuilder {
+	return builder.Set(b, "Name", name).(DropIndexBuilder)
+}
+
+// On sets the table of the index. Required for MySQL and MSSQL.
+func (b DropIndexBuilder) On(table string) DropIndexBuilder {
+	return builder.Set(b, "TableName", table).(DropIndexBuilder)
+}
+
+// WithSchema specifies the schema of the index.
+func (b DropIndexBuilder) WithSchema(schema string) DropIndexBuilder {
+	return builder.Set(b, "Schema", schema).(DropIndexBuilder)
+}
+
+// IfExists only drops the index if it exists. Not available for MySQL.
+func (b DropIndexBuilder) IfExists() DropIndexBuilder {
+	return builder.Set(b, "IfExists", true).(DropIndexBuilder)
+}
+
+// Concurrently drops the index without locking out writes on the table. Only available for PostgreSQL.
+func (b DropIndexBuilder) Concurrently() DropIndexBuilder {
+	return builder.Set(b, "Concurrently", true).(DropIndexBuilder)
+}
+
+// Cascade drops the objects that depend on the index. Only available for PostgreSQL.
+func (b DropIndexBuilder) Cascade() DropIndexBuilder {
+	return builder.Set(b, "Cascade", true).(DropIndexBuilder)
+}
+
+// Restrict refuses to drop the index if any objects depend on it. Only available for PostgreSQL.
+func (b DropIndexBuilder) Restrict() DropIndexBuilder {
+	return builder.Set(b, "Restrict", true).(DropIndexBuilder)
+}
+
+// Dialect specifies the database dialect the statement is rendered for.
+func (b DropIndexBuilder) Dialect(db int) DropIndexBuilder {
+	return builder.Set(b, "Dialect", db).(DropIndexBuilder).hasDialect()
+}
+
+func (b DropIndexBuilder) hasDialect() DropIndexBuilder {
+	return builder.Set(b, "HasDialect", true).(DropIndexBuilder)
+}
+
+// ToSql returns 3 variables filled out with the correct values based on bindings, etc.
+func (b DropIndexBuilder) ToSql() (string, []interface{}, error) {
+	data := builder.GetStruct(b).(dropIndexData)
+	return data.ToSql()
+}
+
+// ToStatements returns the query as a list of statements to be executed one by one.
+func (b DropIndexBuilder) ToStatements() ([]Statement, error) {
+	return singleStatement(b)
+}
+
+// ToSql returns 3 variables filled out with the correct values based on bindings, etc.
+func (d *dropIndexData) ToSql() (sqlStr string, args []interface{}, err error) {
+	if d.Name == "" {
+		err = errors.New("index name is required on drop index statement")
+		return
+	}
+
+	if d.HasDialect && !isDialectSupported(d.Dialect) {
+		err = ErrDialectNotSupported
+		return
+	}
+
+	// MySQL and MSSQL identify the index by its table, the others by its schema.
+	onTable := d.HasDialect && (d.Dialect == MySQL || d.Dialect == MSSQL)
+
+	if onTable && d.TableName == "" {
+		err = errors.New("a table name must be specified on drop index statement for MySQL and MSSQL")
+		return
+	}
+
+	if d.HasDialect && d.Dialect != PostgreSQL && (d.Concurrently || d.Cascade || d.Restrict) {
+		err = errors.New("CONCURRENTLY, CASCADE and RESTRICT are only available for PostgreSQL")
+		return
+	}
+
+	if d.HasDialect && d.Dialect == MySQL && d.IfExists {
+		err = errors.New("DROP INDEX IF EXISTS is not available for MySQL")
+		return
+	}
+
+	if d.Concurrently && d.Cascade {
+		err = errors.New("DROP INDEX CONCURRENTLY doesn't support CASCADE")
+		return
+	}
+
+	var sql strings.Builder
+
+	sql.WriteString("DROP INDEX ")
+
+	if d.Concurrently {
+		sql.WriteString("CONCURRENTLY ")
+	}
+
+	if d.IfExists {
+		sql.WriteString("IF EXISTS ")
+	}
+
+	if onTable {
+		sql.WriteString("\"" + d.Name + "\" ON " + qualifiedName(d.Schema, d.TableName))
+	} else {
+		sql.WriteString(qualifiedName(d.Schema, d.Name))
+		if d.TableName != "" && !d.HasDialect {
+			sql.WriteString(" ON \"" + d.TableName + "\"")
+		}
+	}
+
+	if d.Cascade {
+		sql.WriteString(" CASCADE")
+	} else if d.Restrict {
+		sql.WriteString(" RESTRICT")
+	}
+
+	sql.WriteString(";")
+
+	sqlStr = sql.String()
+	return
+}
@@ -0,0 +1,66 @@
+package bob_test
+
+import (
+	"testing"
+
+	"github.com/aldy505/bob"
+)
+
+func TestDropIndex(t *testing.T) {
+	t.Run("should render without a dialect", func(t *testing.T) {
+		sql, _, err := bob.DropIndex("idx_email").IfExists().ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		result := "DROP INDEX IF EXISTS \"idx_email\";"
+		if sql != result {
+			t.Fatal("sql is not equal to result:", sql)
+		}
+	})
+
+	t.Run("should render per dialect", func(t *testing.T) {
+		cases := []struct {
+			builder bob.DropIndexBuilder
+			result  string
+		}{
+			{bob.DropIndex("idx_email").On("users").Dialect(bob.MySQL), "DROP INDEX \"idx_email\" ON \"users\";"},
+			{bob.DropIndex("idx_email").On("users").WithSchema("dbo").IfExists().Dialect(bob.MSSQL), "DROP INDEX IF EXISTS \"idx_email\" ON \"dbo\".\"users\";"},
+			{bob.DropIndex("idx_email").WithSchema("tenant_1").Concurrently().IfExists().Dialect(bob.PostgreSQL), "DROP INDEX CONCURRENTLY IF EXISTS \"tenant_1\".\"idx_email\";"},
+			{bob.DropIndex("idx_email").On("users").Cascade().Dialect(bob.PostgreSQL), "DROP INDEX \"idx_email\" CASCADE;"},
+			{bob.DropIndex("idx_email").On("users").IfExists().Dialect(bob.SQLite), "DROP INDEX IF EXISTS \"idx_email\";"},
+		}
+
+		for _, c := range cases {
+			sql, _, err := c.builder.ToSql()
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+
+			if sql != c.result {
+				t.Error("sql is not equal to result:", sql)
+			}
+		}
+	})
+}
+
+func TestDropIndex_Error(t *testing.T) {
+	cases := []struct {
+		builder bob.DropIndexBuilder
+		err     string
+	}{
+		{bob.DropIndex(""), "index name is required on drop index statement"},
+		{bob.DropIndex("idx_email").Dialect(bob.MySQL), "a table name must be specified on drop index statement for MySQL and MSSQL"},
+		{bob.DropIndex("idx_email").On("users").Concurrently().Dialect(bob.MSSQL), "CONCURRENTLY, CASCADE and RESTRICT are only available for PostgreSQL"},
+		{bob.DropIndex("idx_email").On("users").IfExists().Dialect(bob.MySQL), "DROP INDEX IF EXISTS is not available for MySQL"},
+		{bob.DropIndex("idx_email").Concurrently().Cascade().Dialect(bob.PostgreSQL), "DROP INDEX CONCURRENTLY doesn't support CASCADE"},
+		{bob.DropIndex("idx_email").Dialect(100), bob.ErrDialectNotSupported.Error()},
+	}
+
+	for _, c := range cases {
+		_, _, err := c.builder.ToSql()
+		if err == nil || err.Error() != c.err {
+			t.Fatalf("Expected %s, got %v", c.err, err)
+		}
+	}
+}
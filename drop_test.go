@@ -1,6 +1,7 @@
 package bob_test
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/aldy505/bob"
@@ -60,3 +61,91 @@ func TestDrop_ErrNoTable(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestDrop_WithSchema(t *testing.T) {
+	sql, _, err := bob.DropTableIfExists("users").WithSchema("tenant_1").ToSql()
+	if err != nil {
+		t.Error(err)
+	}
+
+	result := "DROP TABLE IF EXISTS \"tenant_1\".\"users\";"
+	if sql != result {
+		t.Error("sql is not the same as result: ", sql)
+	}
+}
+
+func TestDrop_MultipleTables(t *testing.T) {
+	t.Run("should drop the tables on a single statement", func(t *testing.T) {
+		sql, _, err := bob.DropTable("a", "b", "c").Cascade().Dialect(bob.PostgreSQL).ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		result := "DROP TABLE \"a\", \"b\", \"c\" CASCADE;"
+		if sql != result {
+			t.Error("sql is not the same as result: ", sql)
+		}
+	})
+
+	t.Run("should drop the tables on separate statements on SQLite", func(t *testing.T) {
+		statements, err := bob.DropTableIfExists("a", "b").Dialect(bob.SQLite).ToStatements()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected := []bob.Statement{
+			{SQL: "DROP TABLE IF EXISTS \"a\";"},
+			{SQL: "DROP TABLE IF EXISTS \"b\";"},
+		}
+		if !reflect.DeepEqual(statements, expected) {
+			t.Errorf("Expected %v, got %v", expected, statements)
+		}
+	})
+}
+
+func TestDrop_Objects(t *testing.T) {
+	cases := []struct {
+		builder bob.DropBuilder
+		result  string
+	}{
+		{bob.DropView("active_users").IfExists(), "DROP VIEW IF EXISTS \"active_users\";"},
+		{bob.DropMaterializedView("daily_stats", "weekly_stats").Dialect(bob.PostgreSQL), "DROP MATERIALIZED VIEW \"daily_stats\", \"weekly_stats\";"},
+		{bob.DropSchema("tenant_1").Cascade().Dialect(bob.PostgreSQL), "DROP SCHEMA \"tenant_1\" CASCADE;"},
+		{bob.DropSchema("tenant_1", "tenant_2").Dialect(bob.MSSQL), "DROP SCHEMA \"tenant_1\"; DROP SCHEMA \"tenant_2\";"},
+		{bob.DropSequence("users_id_seq").WithSchema("tenant_1").Restrict(), "DROP SEQUENCE \"tenant_1\".\"users_id_seq\" RESTRICT;"},
+		{bob.DropType("mood").IfExists().Dialect(bob.PostgreSQL), "DROP TYPE IF EXISTS \"mood\";"},
+		{bob.DropFunction("add(integer, integer)").WithSchema("public").Dialect(bob.PostgreSQL), "DROP FUNCTION \"public\".add(integer, integer);"},
+	}
+
+	for _, c := range cases {
+		sql, _, err := c.builder.ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		if sql != c.result {
+			t.Error("sql is not the same as result: ", sql)
+		}
+	}
+}
+
+func TestDrop_Error(t *testing.T) {
+	cases := []struct {
+		builder bob.DropBuilder
+		err     string
+	}{
+		{bob.DropTable(), "drop statement must specify a table"},
+		{bob.DropView("a", ""), "drop statement must specify a view"},
+		{bob.DropMaterializedView("daily_stats").Dialect(bob.MySQL), "DROP MATERIALIZED VIEW is not available for this dialect"},
+		{bob.DropTable("users").Cascade().Dialect(bob.SQLite), "CASCADE and RESTRICT are not available for this dialect"},
+		{bob.DropSchema("tenant_1").WithSchema("public"), "WithSchema can't be used when dropping a schema"},
+		{bob.DropTable("users").Dialect(100), bob.ErrDialectNotSupported.Error()},
+	}
+
+	for _, c := range cases {
+		_, _, err := c.builder.ToSql()
+		if err == nil || err.Error() != c.err {
+			t.Fatalf("Expected %s, got %v", c.err, err)
+		}
+	}
+}
@@ -39,7 +39,7 @@ func ExampleCreateIndex() {
 	}
 
 	fmt.Print(sql)
-	// Output: CREATE UNIQUE INDEX idx_email ON users (email COLLATE DEFAULT ASC);
+	// Output: CREATE UNIQUE INDEX idx_email ON "users" (email COLLATE DEFAULT ASC);
 }
 
 func ExampleHasTable() {
@@ -0,0 +1,28 @@
+package bob
+
+// expr is a raw SQL expression along with its arguments.
+type expr struct {
+	sql  string
+	args []interface{}
+}
+
+// Expr builds a raw SQL expression, such as NOW() or "counter" + ?, that can be given
+// as a value to the builders. It is inlined into the statement as is, and its arguments
+// are merged along with the other arguments. Any other BobBuilder, including Squirrel's
+// Sqlizer, is accepted the same way as long as it uses the ? placeholder.
+func Expr(sql string, args ...interface{}) BobBuilder {
+	return expr{sql: sql, args: args}
+}
+
+// ToSql returns the expression along with its arguments.
+func (e expr) ToSql() (string, []interface{}, error) {
+	return e.sql, e.args, nil
+}
+
+// renderValue renders a value as a placeholder, or inlines it when it is an expression.
+func renderValue(value interface{}) (string, []interface{}, error) {
+	if e, ok := value.(BobBuilder); ok {
+		return e.ToSql()
+	}
+	return "?", []interface{}{value}, nil
+}
@@ -48,6 +48,11 @@ func (h HasBuilder) ToSql() (string, []interface{}, error) {
 	return data.ToSql()
 }
 
+// ToStatements returns the query as a list of statements to be executed one by one.
+func (h HasBuilder) ToStatements() ([]Statement, error) {
+	return singleStatement(h)
+}
+
 // ToSql returns 3 variables filled out with the correct values based on bindings, etc.
 func (d *hasData) ToSql() (sqlStr string, args []interface{}, err error) {
 	var sql strings.Builder
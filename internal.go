@@ -1,5 +1,7 @@
 package bob
 
+import "strings"
+
 // createArgs should create an argument []interface{} for SQL query
 // I'm using the idiot approach for creating args
 func createArgs(keys ...interface{}) []interface{} {
@@ -13,22 +15,69 @@ func createArgs(keys ...interface{}) []interface{} {
 	return args
 }
 
+// quoteColumns wraps every column name in double quotes and joins them with a comma.
+func quoteColumns(columns []string) string {
+	var quoted []string
+	for _, column := range columns {
+		quoted = append(quoted, "\""+column+"\"")
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// quoteString wraps a value in single quotes to be used as a string literal.
+func quoteString(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// dotted joins the non-empty parts of an unquoted name with dots.
+func dotted(parts ...string) string {
+	var names []string
+	for _, part := range parts {
+		if part != "" {
+			names = append(names, part)
+		}
+	}
+	return strings.Join(names, ".")
+}
+
+// spRename renders a call to MSSQL's sp_rename procedure. The object type is optional.
+func spRename(object, name, objectType string) string {
+	sql := "EXEC sp_rename " + quoteString(object) + ", " + quoteString(name)
+	if objectType != "" {
+		sql += ", " + quoteString(objectType)
+	}
+	return sql + ";"
+}
+
+// isDialectSupported checks if the dialect is one of the supported database dialects.
+func isDialectSupported(dialect int) bool {
+	return dialect == MySQL || dialect == PostgreSQL || dialect == SQLite || dialect == MSSQL
+}
+
+// qualifiedName returns the double quoted name, prefixed with the schema if there is one.
+func qualifiedName(schema, name string) string {
+	if schema != "" {
+		return "\"" + schema + "\".\"" + name + "\""
+	}
+	return "\"" + name + "\""
+}
+
 // isIn checks if an array have a value
-// func isIn(arr []string, value string) bool {
-// 	for _, item := range arr {
-// 		if item == value {
-// 			return true
-// 		}
-// 	}
-// 	return false
-// }
-
-// // findPosition search for value position on an array
-// func findPosition(arr []string, value string) int {
-// 	for i, item := range arr {
-// 		if item == value {
-// 			return i
-// 		}
-// 	}
-// 	return -1
-// }
+func isIn(arr []string, value string) bool {
+	for _, item := range arr {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// findPosition search for value position on an array
+func findPosition(arr []string, value string) int {
+	for i, item := range arr {
+		if item == value {
+			return i
+		}
+	}
+	return -1
+}
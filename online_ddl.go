@@ -0,0 +1,139 @@
+package bob
+
+import (
+	"errors"
+
+	"github.com/lann/builder"
+)
+
+// DDLAlgorithm is the algorithm MySQL uses to perform ALTER TABLE and CREATE INDEX.
+type DDLAlgorithm string
+
+const (
+	AlgorithmDefault DDLAlgorithm = "DEFAULT"
+	AlgorithmInstant DDLAlgorithm = "INSTANT"
+	AlgorithmInplace DDLAlgorithm = "INPLACE"
+	AlgorithmCopy    DDLAlgorithm = "COPY"
+)
+
+// DDLLock is the level of concurrent access MySQL permits while performing ALTER TABLE and CREATE INDEX.
+type DDLLock string
+
+const (
+	LockDefault   DDLLock = "DEFAULT"
+	LockNone      DDLLock = "NONE"
+	LockShared    DDLLock = "SHARED"
+	LockExclusive DDLLock = "EXCLUSIVE"
+)
+
+// Algorithm sets the ALGORITHM clause. Only available for MySQL.
+func (b AlterBuilder) Algorithm(algorithm DDLAlgorithm) AlterBuilder {
+	return builder.Set(b, "Algorithm", algorithm).(AlterBuilder)
+}
+
+// Lock sets the LOCK clause. Only available for MySQL.
+func (b AlterBuilder) Lock(lock DDLLock) AlterBuilder {
+	return builder.Set(b, "Lock", lock).(AlterBuilder)
+}
+
+// StrictOnlineDDL rejects the ALGORITHM and LOCK combinations that MySQL is known
+// to refuse for the given actions, instead of letting the statement fail on the database.
+func (b AlterBuilder) StrictOnlineDDL() AlterBuilder {
+	return builder.Set(b, "Strict", true).(AlterBuilder)
+}
+
+// Algorithm sets the ALGORITHM clause. Only available for MySQL.
+func (i IndexBuilder) Algorithm(algorithm DDLAlgorithm) IndexBuilder {
+	return builder.Set(i, "Algorithm", algorithm).(IndexBuilder)
+}
+
+// Lock sets the LOCK clause. Only available for MySQL.
+func (i IndexBuilder) Lock(lock DDLLock) IndexBuilder {
+	return builder.Set(i, "Lock", lock).(IndexBuilder)
+}
+
+// StrictOnlineDDL rejects the ALGORITHM and LOCK combinations that MySQL is known
+// to refuse for creating an index, instead of letting the statement fail on the database.
+func (i IndexBuilder) StrictOnlineDDL() IndexBuilder {
+	return builder.Set(i, "Strict", true).(IndexBuilder)
+}
+
+// onlineDDLOptions renders the ALGORITHM and LOCK clauses.
+func onlineDDLOptions(algorithm DDLAlgorithm, lock DDLLock) []string {
+	var options []string
+	if algorithm != "" {
+		options = append(options, "ALGORITHM="+string(algorithm))
+	}
+	if lock != "" {
+		options = append(options, "LOCK="+string(lock))
+	}
+	return options
+}
+
+// validateOnlineDDL checks the combinations of ALGORITHM and LOCK that MySQL refuses
+// regardless of the operation.
+func validateOnlineDDL(algorithm DDLAlgorithm, lock DDLLock) error {
+	if algorithm == AlgorithmInstant && lock != "" && lock != LockDefault {
+		return errors.New("ALGORITHM=INSTANT only permits LOCK=DEFAULT")
+	}
+	if algorithm == AlgorithmCopy && lock == LockNone {
+		return errors.New("ALGORITHM=COPY doesn't permit LOCK=NONE")
+	}
+	return nil
+}
+
+// onlineDDL renders the ALGORITHM and LOCK clauses of the ALTER TABLE statement.
+func (d *alterData) onlineDDL() ([]string, error) {
+	if d.Algorithm == "" && d.Lock == "" {
+		return nil, nil
+	}
+
+	if d.HasDialect && d.Dialect != MySQL {
+		return nil, errors.New("ALGORITHM and LOCK are only available for MySQL")
+	}
+
+	if d.Strict {
+		if err := validateOnlineDDL(d.Algorithm, d.Lock); err != nil {
+			return nil, err
+		}
+
+		for _, a := range d.Actions {
+			// CHANGE COLUMN gives the whole column definition, so it may change the type.
+			changesType := a.What == alterAlterColumn && a.ColumnType != "" || a.What == alterChangeColumn
+			changesNullability := a.What == alterAlterColumn && (a.SetNotNull || a.DropNotNull)
+			changesConstraint := a.What == alterAddConstraint || a.What == alterDropConstraint
+
+			if d.Algorithm == AlgorithmInstant && (changesType || changesNullability) {
+				return nil, errors.New("ALGORITHM=INSTANT doesn't support changing the column type or nullability")
+			}
+			if d.Algorithm == AlgorithmInstant && changesConstraint {
+				return nil, errors.New("ALGORITHM=INSTANT doesn't support adding or dropping a constraint")
+			}
+			if d.Algorithm == AlgorithmInplace && changesType {
+				return nil, errors.New("ALGORITHM=INPLACE doesn't support changing the column type")
+			}
+			if d.Lock == LockNone && changesType {
+				return nil, errors.New("LOCK=NONE doesn't support changing the column type")
+			}
+		}
+	}
+
+	return onlineDDLOptions(d.Algorithm, d.Lock), nil
+}
+
+// onlineDDL renders the ALGORITHM and LOCK clauses of the CREATE INDEX statement.
+func (i *indexData) onlineDDL() ([]string, error) {
+	if i.Strict {
+		if err := validateOnlineDDL(i.Algorithm, i.Lock); err != nil {
+			return nil, err
+		}
+		if i.Algorithm == AlgorithmInstant {
+			return nil, errors.New("ALGORITHM=INSTANT doesn't support creating an index")
+		}
+		if (i.Fulltext || i.Spatial) && i.Lock == LockNone {
+			return nil, errors.New("LOCK=NONE doesn't support creating a FULLTEXT or SPATIAL index")
+		}
+	}
+
+	return onlineDDLOptions(i.Algorithm, i.Lock), nil
+}
@@ -0,0 +1,113 @@
+package bob_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aldy505/bob"
+)
+
+func TestOnlineDDL(t *testing.T) {
+	t.Run("should render ALGORITHM and LOCK on every ALTER TABLE statement", func(t *testing.T) {
+		statements, err := bob.
+			AlterTable("users").
+			Dialect(bob.MySQL).
+			Algorithm(bob.AlgorithmInplace).
+			Lock(bob.LockNone).
+			StringColumn("email").
+			AddUnique("uq_users_email").Columns("email").
+			ToStatements()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected := []bob.Statement{
+			{SQL: "ALTER TABLE \"users\" ADD COLUMN \"email\" VARCHAR(255), ADD CONSTRAINT uq_users_email UNIQUE (\"email\"), ALGORITHM=INPLACE, LOCK=NONE"},
+		}
+		if !reflect.DeepEqual(statements, expected) {
+			t.Fatalf("Expected %v, got %v", expected, statements)
+		}
+	})
+
+	t.Run("should render ALGORITHM and LOCK on CREATE INDEX", func(t *testing.T) {
+		sql, _, err := bob.
+			CreateIndex("idx_email").
+			On("users").
+			Columns(bob.IndexColumn{Name: "email"}).
+			Algorithm(bob.AlgorithmInplace).
+			Lock(bob.LockNone).
+			StrictOnlineDDL().
+			ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		result := "CREATE INDEX idx_email ON \"users\" (email) ALGORITHM=INPLACE LOCK=NONE;"
+		if sql != result {
+			t.Fatal("sql is not equal to result:", sql)
+		}
+	})
+
+	t.Run("should allow INSTANT when adding a column", func(t *testing.T) {
+		sql, _, err := bob.
+			AlterTable("users").
+			Dialect(bob.MySQL).
+			Algorithm(bob.AlgorithmInstant).
+			StrictOnlineDDL().
+			IntColumn("age").
+			ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		result := "ALTER TABLE \"users\" ADD COLUMN \"age\" INT, ALGORITHM=INSTANT"
+		if sql != result {
+			t.Fatal("sql is not equal to result:", sql)
+		}
+	})
+}
+
+func TestOnlineDDL_Error(t *testing.T) {
+	t.Run("AlterBuilder", func(t *testing.T) {
+		cases := []struct {
+			builder bob.AlterBuilder
+			err     string
+		}{
+			{bob.AlterTable("users").Dialect(bob.PostgreSQL).Algorithm(bob.AlgorithmInplace).DropColumn("name"), "ALGORITHM and LOCK are only available for MySQL"},
+			{bob.AlterTable("users").Dialect(bob.MySQL).StrictOnlineDDL().Algorithm(bob.AlgorithmInstant).Lock(bob.LockNone).DropColumn("name"), "ALGORITHM=INSTANT only permits LOCK=DEFAULT"},
+			{bob.AlterTable("users").Dialect(bob.MySQL).StrictOnlineDDL().Algorithm(bob.AlgorithmCopy).Lock(bob.LockNone).DropColumn("name"), "ALGORITHM=COPY doesn't permit LOCK=NONE"},
+			{bob.AlterTable("users").Dialect(bob.MySQL).StrictOnlineDDL().Algorithm(bob.AlgorithmInstant).AlterColumn("age").SetType("BIGINT"), "ALGORITHM=INSTANT doesn't support changing the column type or nullability"},
+			{bob.AlterTable("users").Dialect(bob.MySQL).StrictOnlineDDL().Algorithm(bob.AlgorithmInstant).AddUnique("uq_email").Columns("email"), "ALGORITHM=INSTANT doesn't support adding or dropping a constraint"},
+			{bob.AlterTable("users").Dialect(bob.MySQL).StrictOnlineDDL().Algorithm(bob.AlgorithmInplace).AlterColumn("age").SetType("BIGINT"), "ALGORITHM=INPLACE doesn't support changing the column type"},
+			{bob.AlterTable("users").Dialect(bob.MySQL).StrictOnlineDDL().Lock(bob.LockNone).AlterColumn("age").SetType("BIGINT"), "LOCK=NONE doesn't support changing the column type"},
+			{bob.AlterTable("users").Dialect(bob.MySQL).StrictOnlineDDL().Algorithm(bob.AlgorithmInstant).ChangeColumn("name", bob.ColumnDef{Name: "full_name", Type: "VARCHAR(255)"}), "ALGORITHM=INSTANT doesn't support changing the column type or nullability"},
+			{bob.AlterTable("users").Dialect(bob.MySQL).StrictOnlineDDL().Algorithm(bob.AlgorithmInplace).ChangeColumn("name", bob.ColumnDef{Name: "full_name", Type: "VARCHAR(255)"}), "ALGORITHM=INPLACE doesn't support changing the column type"},
+			{bob.AlterTable("users").Dialect(bob.MySQL).StrictOnlineDDL().Lock(bob.LockNone).ChangeColumn("name", bob.ColumnDef{Name: "full_name", Type: "VARCHAR(255)"}), "LOCK=NONE doesn't support changing the column type"},
+		}
+
+		for _, c := range cases {
+			_, _, err := c.builder.ToSql()
+			if err == nil || err.Error() != c.err {
+				t.Fatalf("Expected %s, got %v", c.err, err)
+			}
+		}
+	})
+
+	t.Run("IndexBuilder", func(t *testing.T) {
+		cases := []struct {
+			builder bob.IndexBuilder
+			err     string
+		}{
+			{bob.CreateIndex("idx_email").On("users").Columns(bob.IndexColumn{Name: "email"}).StrictOnlineDDL().Algorithm(bob.AlgorithmInstant), "ALGORITHM=INSTANT doesn't support creating an index"},
+			{bob.CreateIndex("idx_email").On("users").Columns(bob.IndexColumn{Name: "email"}).StrictOnlineDDL().Algorithm(bob.AlgorithmCopy).Lock(bob.LockNone), "ALGORITHM=COPY doesn't permit LOCK=NONE"},
+			{bob.CreateIndex("idx_body").On("posts").Fulltext().Columns(bob.IndexColumn{Name: "body"}).StrictOnlineDDL().Lock(bob.LockNone), "LOCK=NONE doesn't support creating a FULLTEXT or SPATIAL index"},
+		}
+
+		for _, c := range cases {
+			_, _, err := c.builder.ToSql()
+			if err == nil || err.Error() != c.err {
+				t.Fatalf("Expected %s, got %v", c.err, err)
+			}
+		}
+	})
+}
@@ -9,8 +9,12 @@ import (
 type RenameBuilder builder.Builder
 
 type renameData struct {
-	From string
-	To   string
+	From       string
+	To         string
+	Schema     string
+	ToSchema   string
+	Dialect    int
+	HasDialect bool
 }
 
 func init() {
@@ -27,17 +31,105 @@ func (b RenameBuilder) to(name string) RenameBuilder {
 	return builder.Set(b, "To", name).(RenameBuilder)
 }
 
+// WithSchema specifies the schema of the existing table.
+func (b RenameBuilder) WithSchema(schema string) RenameBuilder {
+	return builder.Set(b, "Schema", schema).(RenameBuilder)
+}
+
+// ToSchema moves the table into another schema while renaming it.
+// PostgreSQL and MSSQL move the table before renaming it, on separate statements.
+func (b RenameBuilder) ToSchema(schema string) RenameBuilder {
+	return builder.Set(b, "ToSchema", schema).(RenameBuilder)
+}
+
+// Dialect specifies the database dialect the statement is rendered for.
+// Without a dialect, the MySQL syntax is used.
+func (b RenameBuilder) Dialect(db int) RenameBuilder {
+	return builder.Set(b, "Dialect", db).(RenameBuilder).hasDialect()
+}
+
+func (b RenameBuilder) hasDialect() RenameBuilder {
+	return builder.Set(b, "HasDialect", true).(RenameBuilder)
+}
+
 // ToSql returns 3 variables filled out with the correct values based on bindings, etc.
 func (b RenameBuilder) ToSql() (string, []interface{}, error) {
 	data := builder.GetStruct(b).(renameData)
 	return data.ToSql()
 }
 
+// ToStatements returns the query as a list of statements to be executed one by one.
+// Moving the table into another schema takes more than one statement on some dialects.
+func (b RenameBuilder) ToStatements() ([]Statement, error) {
+	data := builder.GetStruct(b).(renameData)
+	return data.ToStatements()
+}
+
 // ToSql returns 3 variables filled out with the correct values based on bindings, etc.
 func (d *renameData) ToSql() (sqlStr string, args []interface{}, err error) {
+	statements, err := d.ToStatements()
+	if err != nil {
+		return
+	}
+
+	sqlStr, args = joinStatements(statements)
+	return
+}
+
+// ToStatements returns the query as a list of statements to be executed one by one.
+func (d *renameData) ToStatements() (statements []Statement, err error) {
 	if len(d.From) == 0 || d.From == "" || len(d.To) == 0 || d.To == "" {
 		err = errors.New("rename statement must specify a table")
+		return
+	}
+
+	if d.HasDialect && !isDialectSupported(d.Dialect) {
+		err = ErrDialectNotSupported
+		return
+	}
+
+	toSchema := d.ToSchema
+	if toSchema == "" {
+		toSchema = d.Schema
+	}
+	moved := toSchema != d.Schema
+
+	if !moved && d.From == d.To {
+		err = errors.New("the table is renamed to the same name")
+		return
 	}
-	sqlStr = "RENAME TABLE \"" + d.From + "\" TO \"" + d.To + "\";"
+
+	switch {
+	case d.HasDialect && d.Dialect == SQLite:
+		if moved {
+			err = errors.New("SQLite doesn't support moving a table into another schema")
+			return
+		}
+		statements = append(statements, Statement{SQL: "ALTER TABLE " + qualifiedName(d.Schema, d.From) + " RENAME TO \"" + d.To + "\";"})
+
+	case d.HasDialect && d.Dialect == PostgreSQL:
+		from := qualifiedName(d.Schema, d.From)
+		if moved {
+			statements = append(statements, Statement{SQL: "ALTER TABLE " + from + " SET SCHEMA \"" + toSchema + "\";"})
+			from = qualifiedName(toSchema, d.From)
+		}
+		if d.From != d.To {
+			statements = append(statements, Statement{SQL: "ALTER TABLE " + from + " RENAME TO \"" + d.To + "\";"})
+		}
+
+	case d.HasDialect && d.Dialect == MSSQL:
+		from := dotted(d.Schema, d.From)
+		if moved {
+			statements = append(statements, Statement{SQL: "ALTER SCHEMA \"" + toSchema + "\" TRANSFER " + qualifiedName(d.Schema, d.From) + ";"})
+			from = dotted(toSchema, d.From)
+		}
+		if d.From != d.To {
+			statements = append(statements, Statement{SQL: spRename(from, d.To, "")})
+		}
+
+	default:
+		statements = append(statements, Statement{SQL: "RENAME TABLE " + qualifiedName(d.Schema, d.From) + " TO " + qualifiedName(toSchema, d.To) + ";"})
+	}
+
 	return
 }
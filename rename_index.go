@@ -0,0 +1,138 @@
+package bob
+
+import (
+	"errors"
+
+	"github.com/lann/builder"
+)
+
+type RenameIndexBuilder builder.Builder
+
+type renameIndexData struct {
+	TableName  string
+	From       string
+	To         string
+	Schema     string
+	Definition *indexData
+	Dialect    int
+	HasDialect bool
+}
+
+func init() {
+	builder.Register(RenameIndexBuilder{}, renameIndexData{})
+}
+
+func (b RenameIndexBuilder) rename(table, from, to string) RenameIndexBuilder {
+	b = builder.Set(b, "TableName", table).(RenameIndexBuilder)
+	b = builder.Set(b, "From", from).(RenameIndexBuilder)
+	return builder.Set(b, "To", to).(RenameIndexBuilder)
+}
+
+// WithSchema specifies the schema of the table and its index.
+func (b RenameIndexBuilder) WithSchema(schema string) RenameIndexBuilder {
+	return builder.Set(b, "Schema", schema).(RenameIndexBuilder)
+}
+
+// Definition provides the current definition of the index, which is needed on SQLite
+// as it can't rename an index. The index is dropped and created again with the new name.
+func (b RenameIndexBuilder) Definition(index IndexBuilder) RenameIndexBuilder {
+	data := builder.GetStruct(index).(indexData)
+	return builder.Set(b, "Definition", &data).(RenameIndexBuilder)
+}
+
+// Dialect specifies the database dialect the statement is rendered for.
+func (b RenameIndexBuilder) Dialect(db int) RenameIndexBuilder {
+	return builder.Set(b, "Dialect", db).(RenameIndexBuilder).hasDialect()
+}
+
+func (b RenameIndexBuilder) hasDialect() RenameIndexBuilder {
+	return builder.Set(b, "HasDialect", true).(RenameIndexBuilder)
+}
+
+// ToSql returns 3 variables filled out with the correct values based on bindings, etc.
+func (b RenameIndexBuilder) ToSql() (string, []interface{}, error) {
+	data := builder.GetStruct(b).(renameIndexData)
+	return data.ToSql()
+}
+
+// ToStatements returns the query as a list of statements to be executed one by one.
+// SQLite needs more than one statement to rename an index.
+func (b RenameIndexBuilder) ToStatements() ([]Statement, error) {
+	data := builder.GetStruct(b).(renameIndexData)
+	return data.ToStatements()
+}
+
+// ToSql returns 3 variables filled out with the correct values based on bindings, etc.
+func (d *renameIndexData) ToSql() (sqlStr string, args []interface{}, err error) {
+	statements, err := d.ToStatements()
+	if err != nil {
+		return
+	}
+
+	sqlStr, args = joinStatements(statements)
+	return
+}
+
+// ToStatements returns the query as a list of statements to be executed one by one.
+func (d *renameIndexData) ToStatements() (statements []Statement, err error) {
+	if d.TableName == "" {
+		err = errors.New("rename index statement must specify a table")
+		return
+	}
+
+	if d.From == "" || d.To == "" {
+		err = errors.New("rename index statement must specify the index names")
+		return
+	}
+
+	if d.HasDialect && !isDialectSupported(d.Dialect) {
+		err = ErrDialectNotSupported
+		return
+	}
+
+	switch {
+	case d.HasDialect && d.Dialect == MySQL:
+		statements = append(statements, Statement{SQL: "ALTER TABLE " + qualifiedName(d.Schema, d.TableName) + " RENAME INDEX \"" + d.From + "\" TO \"" + d.To + "\";"})
+
+	case d.HasDialect && d.Dialect == MSSQL:
+		statements = append(statements, Statement{SQL: spRename(dotted(d.Schema, d.TableName, d.From), d.To, "INDEX")})
+
+	case d.HasDialect && d.Dialect == SQLite:
+		return d.recreate()
+
+	default:
+		statements = append(statements, Statement{SQL: "ALTER INDEX " + qualifiedName(d.Schema, d.From) + " RENAME TO \"" + d.To + "\";"})
+	}
+
+	return
+}
+
+// recreate renders the statements to drop the index and create it again with the new name.
+func (d *renameIndexData) recreate() ([]Statement, error) {
+	if d.Definition == nil {
+		return nil, errors.New("SQLite doesn't support renaming an index, the index definition must be provided with Definition")
+	}
+
+	if d.Definition.TableName != "" && d.Definition.TableName != d.TableName {
+		return nil, errors.New("the index definition must be of the same table")
+	}
+
+	drop := dropIndexData{Name: d.From, Schema: d.Schema, Dialect: SQLite, HasDialect: true}
+	dropSql, _, err := drop.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	// SQLite qualifies the index name with the schema, not the table.
+	create := *d.Definition
+	create.Name = qualifiedName(d.Schema, d.To)
+	create.TableName = d.TableName
+	create.Schema = ""
+	create.IfNotExists = false
+	createSql, _, err := create.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	return []Statement{{SQL: dropSql}, {SQL: createSql}}, nil
+}
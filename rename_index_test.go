@@ -0,0 +1,71 @@
+package bob_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aldy505/bob"
+)
+
+func TestRenameIndex(t *testing.T) {
+	t.Run("should render per dialect", func(t *testing.T) {
+		cases := []struct {
+			builder bob.RenameIndexBuilder
+			result  string
+		}{
+			{bob.RenameIndex("users", "idx_email", "users_email_idx"), "ALTER INDEX \"idx_email\" RENAME TO \"users_email_idx\";"},
+			{bob.RenameIndex("users", "idx_email", "users_email_idx").WithSchema("tenant_1").Dialect(bob.PostgreSQL), "ALTER INDEX \"tenant_1\".\"idx_email\" RENAME TO \"users_email_idx\";"},
+			{bob.RenameIndex("users", "idx_email", "users_email_idx").Dialect(bob.MySQL), "ALTER TABLE \"users\" RENAME INDEX \"idx_email\" TO \"users_email_idx\";"},
+			{bob.RenameIndex("users", "idx_email", "users_email_idx").WithSchema("dbo").Dialect(bob.MSSQL), "EXEC sp_rename 'dbo.users.idx_email', 'users_email_idx', 'INDEX';"},
+		}
+
+		for _, c := range cases {
+			sql, _, err := c.builder.ToSql()
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+
+			if sql != c.result {
+				t.Error("sql is not equal to result:", sql)
+			}
+		}
+	})
+
+	t.Run("should drop and recreate the index on SQLite", func(t *testing.T) {
+		statements, err := bob.
+			RenameIndex("users", "idx_email", "users_email_idx").
+			Dialect(bob.SQLite).
+			Definition(bob.CreateIndex("idx_email").Unique().Columns(bob.IndexColumn{Name: "email"})).
+			ToStatements()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected := []bob.Statement{
+			{SQL: "DROP INDEX \"idx_email\";"},
+			{SQL: "CREATE UNIQUE INDEX \"users_email_idx\" ON \"users\" (email);"},
+		}
+		if !reflect.DeepEqual(statements, expected) {
+			t.Fatalf("Expected %v, got %v", expected, statements)
+		}
+	})
+}
+
+func TestRenameIndex_Error(t *testing.T) {
+	cases := []struct {
+		builder bob.RenameIndexBuilder
+		err     string
+	}{
+		{bob.RenameIndex("", "idx_email", "users_email_idx"), "rename index statement must specify a table"},
+		{bob.RenameIndex("users", "idx_email", ""), "rename index statement must specify the index names"},
+		{bob.RenameIndex("users", "idx_email", "users_email_idx").Dialect(bob.SQLite), "SQLite doesn't support renaming an index, the index definition must be provided with Definition"},
+		{bob.RenameIndex("users", "idx_email", "users_email_idx").Dialect(bob.SQLite).Definition(bob.CreateIndex("idx_email").On("posts").Columns(bob.IndexColumn{Name: "email"})), "the index definition must be of the same table"},
+	}
+
+	for _, c := range cases {
+		_, _, err := c.builder.ToSql()
+		if err == nil || err.Error() != c.err {
+			t.Fatalf("Expected %s, got %v", c.err, err)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package bob_test
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/aldy505/bob"
@@ -25,4 +26,92 @@ func TestRename(t *testing.T) {
 			t.Error(err)
 		}
 	})
+
+	t.Run("should render the rename per dialect", func(t *testing.T) {
+		cases := []struct {
+			dialect int
+			result  string
+		}{
+			{bob.MySQL, "RENAME TABLE \"users\" TO \"teachers\";"},
+			{bob.PostgreSQL, "ALTER TABLE \"users\" RENAME TO \"teachers\";"},
+			{bob.SQLite, "ALTER TABLE \"users\" RENAME TO \"teachers\";"},
+			{bob.MSSQL, "EXEC sp_rename 'users', 'teachers';"},
+		}
+
+		for _, c := range cases {
+			sql, _, err := bob.RenameTable("users", "teachers").Dialect(c.dialect).ToSql()
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+
+			if sql != c.result {
+				t.Error("sql is not the same as result: ", sql)
+			}
+		}
+	})
+
+	t.Run("should expect an error for unsupported dialect", func(t *testing.T) {
+		_, _, err := bob.RenameTable("users", "teachers").Dialect(100).ToSql()
+		if err != bob.ErrDialectNotSupported {
+			t.Error("should throw an error, it didn't:", err)
+		}
+	})
+
+	t.Run("should rename across schemas per dialect", func(t *testing.T) {
+		cases := []struct {
+			dialect    int
+			statements []bob.Statement
+		}{
+			{bob.MySQL, []bob.Statement{
+				{SQL: "RENAME TABLE \"tenant_1\".\"users\" TO \"archive\".\"old_users\";"},
+			}},
+			{bob.PostgreSQL, []bob.Statement{
+				{SQL: "ALTER TABLE \"tenant_1\".\"users\" SET SCHEMA \"archive\";"},
+				{SQL: "ALTER TABLE \"archive\".\"users\" RENAME TO \"old_users\";"},
+			}},
+			{bob.MSSQL, []bob.Statement{
+				{SQL: "ALTER SCHEMA \"archive\" TRANSFER \"tenant_1\".\"users\";"},
+				{SQL: "EXEC sp_rename 'archive.users', 'old_users';"},
+			}},
+		}
+
+		for _, c := range cases {
+			statements, err := bob.RenameTable("users", "old_users").WithSchema("tenant_1").ToSchema("archive").Dialect(c.dialect).ToStatements()
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+
+			if !reflect.DeepEqual(statements, c.statements) {
+				t.Errorf("Expected %v, got %v", c.statements, statements)
+			}
+		}
+	})
+
+	t.Run("should rename within a schema", func(t *testing.T) {
+		sql, _, err := bob.RenameTable("users", "teachers").WithSchema("tenant_1").Dialect(bob.PostgreSQL).ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		result := "ALTER TABLE \"tenant_1\".\"users\" RENAME TO \"teachers\";"
+		if sql != result {
+			t.Error("sql is not the same as result: ", sql)
+		}
+	})
+
+	t.Run("should expect an error for renaming a table to the same name", func(t *testing.T) {
+		for _, dialect := range []int{bob.MySQL, bob.PostgreSQL, bob.SQLite, bob.MSSQL} {
+			_, _, err := bob.RenameTable("users", "users").WithSchema("tenant_1").Dialect(dialect).ToSql()
+			if err == nil || err.Error() != "the table is renamed to the same name" {
+				t.Error("should throw an error, it didn't:", err)
+			}
+		}
+	})
+
+	t.Run("should expect an error for moving schemas on SQLite", func(t *testing.T) {
+		_, _, err := bob.RenameTable("users", "teachers").ToSchema("archive").Dialect(bob.SQLite).ToSql()
+		if err == nil || err.Error() != "SQLite doesn't support moving a table into another schema" {
+			t.Error("should throw an error, it didn't:", err)
+		}
+	})
 }
@@ -0,0 +1,46 @@
+package bob
+
+import "strings"
+
+// Statement is a single SQL statement with its own arguments.
+// Some builders need more than one statement to do what is asked,
+// those statements can be executed one by one on drivers that reject
+// a multi-statement query string.
+type Statement struct {
+	SQL  string
+	Args []interface{}
+}
+
+// StatementBuilder is implemented by every builder in Bob.
+// ToSql joins the statements returned by ToStatements into a single query string.
+type StatementBuilder interface {
+	BobBuilder
+	ToStatements() ([]Statement, error)
+}
+
+// singleStatement wraps the query of a builder that always renders
+// a single statement into a list of statements.
+func singleStatement(b BobBuilder) ([]Statement, error) {
+	sql, args, err := b.ToSql()
+	if err != nil {
+		return nil, err
+	}
+	return []Statement{{SQL: sql, Args: args}}, nil
+}
+
+// joinStatements joins multiple statements into a single query string,
+// separated by a space, with their arguments appended in order.
+// When there are more than one statement, each of them is terminated with a semicolon.
+func joinStatements(statements []Statement) (sqlStr string, args []interface{}) {
+	var sqls []string
+	for _, statement := range statements {
+		sql := statement.SQL
+		if len(statements) > 1 && !strings.HasSuffix(sql, ";") {
+			sql += ";"
+		}
+		sqls = append(sqls, sql)
+		args = append(args, statement.Args...)
+	}
+	sqlStr = strings.Join(sqls, " ")
+	return
+}
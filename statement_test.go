@@ -0,0 +1,48 @@
+package bob_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aldy505/bob"
+)
+
+// Every builder must implement StatementBuilder.
+var (
+	_ bob.StatementBuilder = bob.AlterBuilder{}
+	_ bob.StatementBuilder = bob.CreateBuilder{}
+	_ bob.StatementBuilder = bob.DropBuilder{}
+	_ bob.StatementBuilder = bob.DropIndexBuilder{}
+	_ bob.StatementBuilder = bob.HasBuilder{}
+	_ bob.StatementBuilder = bob.IndexBuilder{}
+	_ bob.StatementBuilder = bob.RenameBuilder{}
+	_ bob.StatementBuilder = bob.RenameIndexBuilder{}
+	_ bob.StatementBuilder = bob.TruncateBuilder{}
+	_ bob.StatementBuilder = bob.UpsertBuilder{}
+)
+
+func TestToStatements(t *testing.T) {
+	t.Run("should return a single statement with args", func(t *testing.T) {
+		statements, err := bob.HasTable("users").PlaceholderFormat(bob.Dollar).ToStatements()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		result := []bob.Statement{
+			{
+				SQL:  "SELECT * FROM information_schema.tables WHERE table_name = $1 AND table_schema = current_schema();",
+				Args: []interface{}{"users"},
+			},
+		}
+		if !reflect.DeepEqual(statements, result) {
+			t.Fatal("statements are not equal to result:", statements)
+		}
+	})
+
+	t.Run("should emit the builder error", func(t *testing.T) {
+		_, err := bob.CreateIndex("").ToStatements()
+		if err == nil || err.Error() != "index name is required on create index statement" {
+			t.Fatal("should throw an error, it didn't:", err)
+		}
+	})
+}
@@ -2,6 +2,7 @@ package bob
 
 import (
 	"errors"
+	"strings"
 
 	"github.com/lann/builder"
 )
@@ -9,16 +10,59 @@ import (
 type TruncateBuilder builder.Builder
 
 type truncateData struct {
-	TableName string
+	Tables           []string
+	Schema           string
+	RestartIdentity  bool
+	ContinueIdentity bool
+	Cascade          bool
+	Only             bool
+	Dialect          int
+	HasDialect       bool
 }
 
 func init() {
 	builder.Register(TruncateBuilder{}, truncateData{})
 }
 
-// Truncate sets which table to be dropped
-func (b TruncateBuilder) truncate(name string) TruncateBuilder {
-	return builder.Set(b, "TableName", name).(TruncateBuilder)
+// Truncate sets which tables to be truncated
+func (b TruncateBuilder) truncate(names []string) TruncateBuilder {
+	return builder.Set(b, "Tables", append([]string(nil), names...)).(TruncateBuilder)
+}
+
+// WithSchema specifies the schema of the truncated table.
+func (b TruncateBuilder) WithSchema(schema string) TruncateBuilder {
+	return builder.Set(b, "Schema", schema).(TruncateBuilder)
+}
+
+// RestartIdentity resets the sequences owned by the columns of the tables. Only available for PostgreSQL,
+// and on SQLite it resets the AUTOINCREMENT counters, which needs at least one table to use AUTOINCREMENT.
+func (b TruncateBuilder) RestartIdentity() TruncateBuilder {
+	return builder.Set(b, "RestartIdentity", true).(TruncateBuilder)
+}
+
+// ContinueIdentity keeps the sequences unchanged. Only available for PostgreSQL and SQLite,
+// where the AUTOINCREMENT counters are kept by default.
+func (b TruncateBuilder) ContinueIdentity() TruncateBuilder {
+	return builder.Set(b, "ContinueIdentity", true).(TruncateBuilder)
+}
+
+// Cascade also truncates the tables that have foreign keys to the tables. Only available for PostgreSQL.
+func (b TruncateBuilder) Cascade() TruncateBuilder {
+	return builder.Set(b, "Cascade", true).(TruncateBuilder)
+}
+
+// Only doesn't truncate the descendant tables. Only available for PostgreSQL.
+func (b TruncateBuilder) Only() TruncateBuilder {
+	return builder.Set(b, "Only", true).(TruncateBuilder)
+}
+
+// Dialect specifies the database dialect the statement is rendered for.
+func (b TruncateBuilder) Dialect(db int) TruncateBuilder {
+	return builder.Set(b, "Dialect", db).(TruncateBuilder).hasDialect()
+}
+
+func (b TruncateBuilder) hasDialect() TruncateBuilder {
+	return builder.Set(b, "HasDialect", true).(TruncateBuilder)
 }
 
 // ToSql returns 3 variables filled out with the correct values based on bindings, etc.
@@ -27,11 +71,100 @@ func (b TruncateBuilder) ToSql() (string, []interface{}, error) {
 	return data.ToSql()
 }
 
+// ToStatements returns the query as a list of statements to be executed one by one.
+// MySQL and MSSQL truncate a table per statement, and SQLite deletes the rows instead.
+func (b TruncateBuilder) ToStatements() ([]Statement, error) {
+	data := builder.GetStruct(b).(truncateData)
+	return data.ToStatements()
+}
+
 // ToSql returns 3 variables filled out with the correct values based on bindings, etc.
 func (d *truncateData) ToSql() (sqlStr string, args []interface{}, err error) {
-	if len(d.TableName) == 0 || d.TableName == "" {
+	statements, err := d.ToStatements()
+	if err != nil {
+		return
+	}
+
+	sqlStr, args = joinStatements(statements)
+	return
+}
+
+// ToStatements returns the query as a list of statements to be executed one by one.
+func (d *truncateData) ToStatements() (statements []Statement, err error) {
+	if len(d.Tables) == 0 {
 		err = errors.New("truncate statement must specify a table")
+		return
 	}
-	sqlStr = "TRUNCATE \"" + d.TableName + "\";"
+	for _, table := range d.Tables {
+		if table == "" {
+			err = errors.New("truncate statement must specify a table")
+			return
+		}
+	}
+
+	if d.HasDialect && !isDialectSupported(d.Dialect) {
+		err = ErrDialectNotSupported
+		return
+	}
+
+	if d.RestartIdentity && d.ContinueIdentity {
+		err = errors.New("cannot restart and continue the identity at the same time")
+		return
+	}
+
+	if d.HasDialect && d.Dialect != PostgreSQL && (d.Cascade || d.Only || (d.RestartIdentity || d.ContinueIdentity) && d.Dialect != SQLite) {
+		err = errors.New("RESTART IDENTITY, CONTINUE IDENTITY, CASCADE and ONLY are only available for PostgreSQL")
+		return
+	}
+
+	var tables []string
+	for _, table := range d.Tables {
+		tables = append(tables, qualifiedName(d.Schema, table))
+	}
+
+	switch {
+	case d.HasDialect && (d.Dialect == MySQL || d.Dialect == MSSQL):
+		for _, table := range tables {
+			statements = append(statements, Statement{SQL: "TRUNCATE TABLE " + table + ";"})
+		}
+
+	case d.HasDialect && d.Dialect == SQLite:
+		// SQLite doesn't have TRUNCATE. The AUTOINCREMENT counters are kept on sqlite_sequence,
+		// which only exists once a table uses AUTOINCREMENT, so they are only reset on request.
+		var names []string
+		for i, table := range tables {
+			statements = append(statements, Statement{SQL: "DELETE FROM " + table + ";"})
+			names = append(names, quoteString(d.Tables[i]))
+		}
+		if d.RestartIdentity {
+			statements = append(statements, Statement{SQL: "DELETE FROM " + qualifiedName(d.Schema, "sqlite_sequence") + " WHERE name IN (" + strings.Join(names, ", ") + ");"})
+		}
+
+	default:
+		var sql strings.Builder
+
+		sql.WriteString("TRUNCATE ")
+
+		if d.Only {
+			sql.WriteString("ONLY ")
+		}
+
+		sql.WriteString(strings.Join(tables, ", "))
+
+		if d.RestartIdentity {
+			sql.WriteString(" RESTART IDENTITY")
+		} else if d.ContinueIdentity {
+			sql.WriteString(" CONTINUE IDENTITY")
+		}
+
+		if d.Cascade {
+			sql.WriteString(" CASCADE")
+		}
+
+		sql.WriteString(";")
+
+		statements = append(statements, Statement{SQL: sql.String()})
+	}
+
 	return
 }
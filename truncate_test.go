@@ -1,6 +1,7 @@
 package bob_test
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/aldy505/bob"
@@ -25,4 +26,109 @@ func TestTruncate(t *testing.T) {
 			t.Error(err)
 		}
 	})
+
+	t.Run("should be able to truncate a table on a schema", func(t *testing.T) {
+		sql, _, err := bob.Truncate("users").WithSchema("tenant_1").ToSql()
+		if err != nil {
+			t.Error(err)
+		}
+
+		result := "TRUNCATE \"tenant_1\".\"users\";"
+		if sql != result {
+			t.Error("sql is not the same as result: ", sql)
+		}
+	})
+}
+
+func TestTruncate_Dialect(t *testing.T) {
+	t.Run("should render the PostgreSQL options", func(t *testing.T) {
+		sql, _, err := bob.Truncate("users", "posts").Only().RestartIdentity().Cascade().Dialect(bob.PostgreSQL).ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		result := "TRUNCATE ONLY \"users\", \"posts\" RESTART IDENTITY CASCADE;"
+		if sql != result {
+			t.Error("sql is not the same as result: ", sql)
+		}
+	})
+
+	t.Run("should truncate a table per statement on MSSQL", func(t *testing.T) {
+		statements, err := bob.Truncate("users", "posts").WithSchema("dbo").Dialect(bob.MSSQL).ToStatements()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected := []bob.Statement{
+			{SQL: "TRUNCATE TABLE \"dbo\".\"users\";"},
+			{SQL: "TRUNCATE TABLE \"dbo\".\"posts\";"},
+		}
+		if !reflect.DeepEqual(statements, expected) {
+			t.Errorf("Expected %v, got %v", expected, statements)
+		}
+	})
+
+	t.Run("should delete the rows on SQLite", func(t *testing.T) {
+		statements, err := bob.Truncate("users", "posts").Dialect(bob.SQLite).ToStatements()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected := []bob.Statement{
+			{SQL: "DELETE FROM \"users\";"},
+			{SQL: "DELETE FROM \"posts\";"},
+		}
+		if !reflect.DeepEqual(statements, expected) {
+			t.Errorf("Expected %v, got %v", expected, statements)
+		}
+	})
+
+	t.Run("should reset the counters on SQLite with RestartIdentity", func(t *testing.T) {
+		statements, err := bob.Truncate("users", "posts").RestartIdentity().Dialect(bob.SQLite).ToStatements()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected := []bob.Statement{
+			{SQL: "DELETE FROM \"users\";"},
+			{SQL: "DELETE FROM \"posts\";"},
+			{SQL: "DELETE FROM \"sqlite_sequence\" WHERE name IN ('users', 'posts');"},
+		}
+		if !reflect.DeepEqual(statements, expected) {
+			t.Errorf("Expected %v, got %v", expected, statements)
+		}
+	})
+
+	t.Run("should keep the counters on SQLite with ContinueIdentity", func(t *testing.T) {
+		sql, _, err := bob.Truncate("users").ContinueIdentity().Dialect(bob.SQLite).ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		result := "DELETE FROM \"users\";"
+		if sql != result {
+			t.Error("sql is not the same as result: ", sql)
+		}
+	})
+}
+
+func TestTruncate_Error(t *testing.T) {
+	cases := []struct {
+		builder bob.TruncateBuilder
+		err     string
+	}{
+		{bob.Truncate(), "truncate statement must specify a table"},
+		{bob.Truncate("users").RestartIdentity().ContinueIdentity(), "cannot restart and continue the identity at the same time"},
+		{bob.Truncate("users").Cascade().Dialect(bob.MySQL), "RESTART IDENTITY, CONTINUE IDENTITY, CASCADE and ONLY are only available for PostgreSQL"},
+		{bob.Truncate("users").RestartIdentity().Dialect(bob.MySQL), "RESTART IDENTITY, CONTINUE IDENTITY, CASCADE and ONLY are only available for PostgreSQL"},
+		{bob.Truncate("users").ContinueIdentity().Dialect(bob.MSSQL), "RESTART IDENTITY, CONTINUE IDENTITY, CASCADE and ONLY are only available for PostgreSQL"},
+		{bob.Truncate("users").Dialect(100), bob.ErrDialectNotSupported.Error()},
+	}
+
+	for _, c := range cases {
+		_, _, err := c.builder.ToSql()
+		if err == nil || err.Error() != c.err {
+			t.Fatalf("Expected %s, got %v", c.err, err)
+		}
+	}
 }
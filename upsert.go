@@ -2,6 +2,8 @@ package bob
 
 import (
 	"errors"
+	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/lann/builder"
@@ -14,8 +16,23 @@ type upsertData struct {
 	Into        string
 	Columns     []string
 	Values      [][]interface{}
+	Records     []interface{}
+	Select      BobBuilder
 	Key         []interface{}
+	Conflict    []string
+	Constraint  string
+	Where       string
+	WhereArgs   []interface{}
 	Replace     [][]interface{}
+	UpdateWhere string
+	UpdateArgs  []interface{}
+	FromInsert  []string
+	UpdateAll   bool
+	Except      []string
+	RowAlias    string
+	DoNothing   bool
+	Returning   []string
+	Inserted    bool
 	Placeholder string
 }
 
@@ -41,10 +58,54 @@ func (u UpsertBuilder) Columns(columns ...string) UpsertBuilder {
 // Values sets the values in relation with the columns.
 // Please not that only string, int, and bool type are supported.
 // Inputting other types other than those might result in your SQL not working properly.
+// Raw SQL expressions can be given with Expr.
 func (u UpsertBuilder) Values(values ...interface{}) UpsertBuilder {
 	return builder.Append(u, "Values", values).(UpsertBuilder)
 }
 
+// SetMap adds a row from a map of column names to values. Unless the columns are given with Columns,
+// they are taken from the keys of the first row, in alphabetical order. Every row must have the same keys.
+func (u UpsertBuilder) SetMap(values map[string]interface{}) UpsertBuilder {
+	return builder.Append(u, "Records", values).(UpsertBuilder)
+}
+
+// Rows adds a row for every map, just like SetMap.
+func (u UpsertBuilder) Rows(rows []map[string]interface{}) UpsertBuilder {
+	for _, row := range rows {
+		u = u.SetMap(row)
+	}
+	return u
+}
+
+// Struct adds a row from the fields of a struct, or a pointer to a struct. The column names are read
+// just like CreateTableFromStruct: from the `db` tag, or the lowercased field name if there is none.
+// Unless the columns are given with Columns, they are taken from the fields of the first row, in their order.
+// Otherwise only the given columns are picked from the struct.
+func (u UpsertBuilder) Struct(v interface{}) UpsertBuilder {
+	return builder.Append(u, "Records", v).(UpsertBuilder)
+}
+
+// Structs adds a row for every struct of a slice, just like Struct.
+func (u UpsertBuilder) Structs(slice interface{}) UpsertBuilder {
+	v := reflect.ValueOf(slice)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		// The error is returned by ToSql.
+		return builder.Append(u, "Records", errors.New("Structs must be given a slice of structs")).(UpsertBuilder)
+	}
+	for i := 0; i < v.Len(); i++ {
+		u = u.Struct(v.Index(i).Interface())
+	}
+	return u
+}
+
+// Select inserts the rows returned by a query instead of Values, such as Squirrel's SelectBuilder
+// or any other BobBuilder using the ? placeholder. Its columns must be in the same order as Columns.
+// SQLite needs a WHERE clause on the query, even if it is just WHERE true, to tell the ON CONFLICT
+// clause apart from a join. On MSSQL, the query is used as the source of the MERGE statement.
+func (u UpsertBuilder) Select(query BobBuilder) UpsertBuilder {
+	return builder.Set(u, "Select", query).(UpsertBuilder)
+}
+
 // Key specifies which key to be checked on conflict.
 // Must be used on PostgreSQL and SQLite.
 func (u UpsertBuilder) Key(key ...interface{}) UpsertBuilder {
@@ -58,12 +119,81 @@ func (u UpsertBuilder) Key(key ...interface{}) UpsertBuilder {
 	return builder.Extend(u, "Key", []interface{}{column, value}).(UpsertBuilder)
 }
 
+// ConflictColumns specifies the columns of the unique key to be checked on conflict,
+// which can be a composite key. On MSSQL, it renders a MERGE statement which matches every
+// inserted row on these columns. MySQL checks every unique key of the table, so they are not needed there.
+func (u UpsertBuilder) ConflictColumns(columns ...string) UpsertBuilder {
+	return builder.Extend(u, "Conflict", columns).(UpsertBuilder)
+}
+
+// OnConstraint specifies the name of the constraint to be checked on conflict,
+// instead of its columns. Only available for PostgreSQL.
+func (u UpsertBuilder) OnConstraint(name string) UpsertBuilder {
+	return builder.Set(u, "Constraint", name).(UpsertBuilder)
+}
+
+// ConflictWhere specifies the predicate of a partial unique index to be checked on conflict,
+// for example "deleted_at IS NULL". Only available for PostgreSQL and SQLite.
+func (u UpsertBuilder) ConflictWhere(expr string, args ...interface{}) UpsertBuilder {
+	u = builder.Set(u, "Where", expr).(UpsertBuilder)
+	return builder.Set(u, "WhereArgs", args).(UpsertBuilder)
+}
+
 // Replace sets the column and value respectively for the data to be changed on
-// a specific row.
+// a specific row. The value can be a raw SQL expression given with Expr.
 func (u UpsertBuilder) Replace(column interface{}, value interface{}) UpsertBuilder {
 	return builder.Append(u, "Replace", []interface{}{column, value}).(UpsertBuilder)
 }
 
+// UpdateWhere only updates the existing row when the condition is met, for example
+// `"users"."version" < excluded."version"` on PostgreSQL. It renders a WHERE clause on PostgreSQL and SQLite,
+// a WHEN MATCHED AND condition on MSSQL, and wraps every assignment with IF(cond, new, old) on MySQL.
+// As MySQL evaluates the assignments from left to right, the columns used by the condition must be updated last.
+func (u UpsertBuilder) UpdateWhere(expr string, args ...interface{}) UpsertBuilder {
+	u = builder.Set(u, "UpdateWhere", expr).(UpsertBuilder)
+	return builder.Set(u, "UpdateArgs", args).(UpsertBuilder)
+}
+
+// UpdateFromInsert updates the columns of the existing row with the values of the incoming row,
+// so every row of a multi-row upsert is updated with its own values. It renders excluded."col"
+// on PostgreSQL and SQLite, and VALUES("col") on MySQL unless a row alias is given with RowAlias.
+func (u UpsertBuilder) UpdateFromInsert(columns ...string) UpsertBuilder {
+	return builder.Extend(u, "FromInsert", columns).(UpsertBuilder)
+}
+
+// UpdateAllExcept updates every inserted column except the given ones, usually the unique keys,
+// with the values of the incoming row, just like UpdateFromInsert.
+func (u UpsertBuilder) UpdateAllExcept(columns ...string) UpsertBuilder {
+	u = builder.Set(u, "UpdateAll", true).(UpsertBuilder)
+	return builder.Extend(u, "Except", columns).(UpsertBuilder)
+}
+
+// RowAlias sets the alias of the incoming row on MySQL 8.0.19 and later, which replaces
+// the deprecated VALUES() function on UpdateFromInsert and UpdateAllExcept. Only available for MySQL.
+func (u UpsertBuilder) RowAlias(alias string) UpsertBuilder {
+	return builder.Set(u, "RowAlias", alias).(UpsertBuilder)
+}
+
+// DoNothing only inserts the rows that don't exist yet, leaving the existing rows untouched.
+// MySQL renders a no-op ON DUPLICATE KEY UPDATE rather than INSERT IGNORE,
+// as the latter also ignores other errors such as invalid values.
+func (u UpsertBuilder) DoNothing() UpsertBuilder {
+	return builder.Set(u, "DoNothing", true).(UpsertBuilder)
+}
+
+// Returning returns the given columns of the inserted or updated rows, with RETURNING on
+// PostgreSQL and SQLite 3.35 or later, and OUTPUT on MSSQL. "*" returns every column.
+// MySQL doesn't have a way to return the rows, so an error is returned there.
+func (u UpsertBuilder) Returning(columns ...string) UpsertBuilder {
+	return builder.Extend(u, "Returning", columns).(UpsertBuilder)
+}
+
+// ReturningInsertedFlag also returns an "inserted" column, which is true when the row was
+// inserted and false when it was updated, based on the xmax system column. Only available for PostgreSQL.
+func (u UpsertBuilder) ReturningInsertedFlag() UpsertBuilder {
+	return builder.Set(u, "Inserted", true).(UpsertBuilder)
+}
+
 // PlaceholderFormat changes the default placeholder (?) to desired placeholder.
 func (u UpsertBuilder) PlaceholderFormat(f string) UpsertBuilder {
 	return builder.Set(u, "Placeholder", f).(UpsertBuilder)
@@ -75,6 +205,11 @@ func (u UpsertBuilder) ToSql() (string, []interface{}, error) {
 	return data.ToSql()
 }
 
+// ToStatements returns the query as a list of statements to be executed one by one.
+func (u UpsertBuilder) ToStatements() ([]Statement, error) {
+	return singleStatement(u)
+}
+
 // ToSql returns 3 variables filled out with the correct values based on bindings, etc.
 func (d *upsertData) ToSql() (sqlStr string, args []interface{}, err error) {
 	if len(d.Into) == 0 || d.Into == "" {
@@ -82,31 +217,129 @@ func (d *upsertData) ToSql() (sqlStr string, args []interface{}, err error) {
 		return
 	}
 
+	err = d.records()
+	if err != nil {
+		return
+	}
+
 	if len(d.Columns) == 0 || d.Columns[0] == "" {
 		err = errors.New("upsert statement must have at least one column")
 		return
 	}
 
-	if len(d.Values) == 0 {
+	if d.Select != nil && len(d.Values) > 0 {
+		err = errors.New("Select cannot be used along with Values")
+		return
+	}
+
+	if d.Select == nil && len(d.Values) == 0 {
 		err = errors.New("upsert statements must have at least one set of values")
 		return
 	}
 
-	if len(d.Replace) == 0 {
-		err = errors.New("upsert statement must have at least one key value pair to be replaced")
+	for _, row := range d.Values {
+		if len(row) != len(d.Columns) {
+			err = errors.New("every set of values must have the same length as the columns")
+			return
+		}
+	}
+
+	if !isDialectSupported(d.Dialect) {
+		err = ErrDialectNotSupported
+		return
+	}
+
+	if len(d.Replace) > 0 && d.DoNothing {
+		err = errors.New("DoNothing cannot be used along with Replace")
+		return
+	}
+
+	if (len(d.FromInsert) > 0 || d.UpdateAll) && d.DoNothing {
+		err = errors.New("DoNothing cannot be used along with UpdateFromInsert or UpdateAllExcept")
+		return
+	}
+
+	if d.UpdateWhere != "" && d.DoNothing {
+		err = errors.New("DoNothing cannot be used along with UpdateWhere")
+		return
+	}
+
+	if d.RowAlias != "" && d.Dialect != MySQL {
+		err = errors.New("RowAlias is only available for MySQL")
+		return
+	}
+
+	if d.RowAlias != "" && d.Select != nil {
+		err = errors.New("RowAlias cannot be used along with Select")
+		return
+	}
+
+	if d.Select != nil && d.Dialect == MSSQL && len(d.Key) > 0 {
+		err = errors.New("MSSQL upsert from Select must use ConflictColumns instead of Key")
+		return
+	}
+
+	var replaces []string
+	var replaceArgs []interface{}
+	if !d.DoNothing {
+		replaces, replaceArgs, err = d.assignments()
+		if err != nil {
+			return
+		}
+
+		if len(replaces) == 0 {
+			err = errors.New("upsert statement must have at least one key value pair to be replaced")
+			return
+		}
+	}
+
+	if len(d.Key) > 0 && len(d.Conflict) > 0 {
+		err = errors.New("Key and ConflictColumns cannot be used together")
+		return
+	}
+
+	if d.Constraint != "" && d.Dialect != PostgreSQL {
+		err = errors.New("ON CONFLICT ON CONSTRAINT is only available for PostgreSQL")
+		return
+	}
+
+	if d.Where != "" && d.Dialect != PostgreSQL && d.Dialect != SQLite {
+		err = errors.New("ConflictWhere is only available for PostgreSQL and SQLite")
+		return
+	}
+
+	if (len(d.Returning) > 0 || d.Inserted) && d.Dialect == MySQL {
+		err = errors.New("MySQL doesn't support returning the upserted rows, please use LAST_INSERT_ID() instead")
+		return
+	}
+
+	if d.Inserted && d.Dialect != PostgreSQL {
+		err = errors.New("ReturningInsertedFlag is only available for PostgreSQL")
+		return
+	}
+
+	if d.merge() {
+		var merge string
+		merge, args, err = d.mergeStatement(replaces, replaceArgs)
+		if err != nil {
+			return
+		}
+		sqlStr = ReplacePlaceholder(merge, d.placeholder())
 		return
 	}
 
 	var sql strings.Builder
 
+	var match string
+	var matchArgs []interface{}
 	if d.Dialect == MSSQL {
-		if len(d.Key) == 0 {
-			err = errors.New("unique key and value must be provided for MS SQL")
+		match, matchArgs, err = d.matchRow()
+		if err != nil {
 			return
 		}
 
-		sql.WriteString("IF NOT EXISTS (SELECT * FROM \"" + d.Into + "\" WHERE \"" + d.Key[0].(string) + "\" = ?) ")
-		args = append(args, d.Key[1])
+		sql.WriteString("IF NOT EXISTS (SELECT * FROM \"" + d.Into + "\" WHERE " + match + ") ")
+		args = append(args, matchArgs...)
 	}
 
 	sql.WriteString("INSERT INTO ")
@@ -122,45 +355,67 @@ func (d *upsertData) ToSql() (sqlStr string, args []interface{}, err error) {
 	sql.WriteString(strings.Join(columns, ", "))
 	sql.WriteString(") ")
 
-	sql.WriteString("VALUES ")
-
-	var values []string
-	for i := 0; i < len(d.Values); i++ {
-		var tempValues []string
-		for _, v := range d.Values[i] {
-			args = append(args, v)
-			tempValues = append(tempValues, "?")
-		}
-		values = append(values, "("+strings.Join(tempValues, ", ")+")")
+	output := d.output()
+	if output != "" {
+		sql.WriteString(output + " ")
 	}
 
-	sql.WriteString(strings.Join(values, ", "))
-	sql.WriteString(" ")
-
-	var replaces []string
-	for i := 0; i < len(d.Replace); i++ {
-		args = append(args, d.Replace[i][1])
-		replace := "\"" + d.Replace[i][0].(string) + "\" = ?"
-		replaces = append(replaces, replace)
+	var source string
+	var sourceArgs []interface{}
+	source, sourceArgs, err = d.source()
+	if err != nil {
+		return
 	}
+	sql.WriteString(source)
+	args = append(args, sourceArgs...)
 
 	if d.Dialect == MySQL {
 		// INSERT INTO table (col) VALUES (values) ON DUPLICATE KEY UPDATE col = value
 
-		sql.WriteString("ON DUPLICATE KEY UPDATE ")
-		sql.WriteString(strings.Join(replaces, ", "))
+		if d.RowAlias != "" {
+			sql.WriteString(" AS " + d.RowAlias)
+		}
+
+		sql.WriteString(" ON DUPLICATE KEY UPDATE ")
+		if d.DoNothing {
+			column := "\"" + d.Columns[0] + "\""
+			sql.WriteString(column + " = " + column)
+		} else {
+			sql.WriteString(strings.Join(replaces, ", "))
+			args = append(args, replaceArgs...)
+		}
 	} else if d.Dialect == PostgreSQL || d.Dialect == SQLite {
 		// INSERT INTO players (user_name, age) VALUES('steven', 32) ON CONFLICT(user_name) DO UPDATE SET age=excluded.age;
 
-		if len(d.Key) == 0 {
-			err = errors.New("unique key must be provided for PostgreSQL and SQLite")
+		var target string
+		var targetArgs []interface{}
+		target, targetArgs, err = d.conflictTarget()
+		if err != nil {
 			return
 		}
 
-		sql.WriteString("ON CONFLICT ")
-		sql.WriteString("(\"" + d.Key[0].(string) + "\") ")
-		sql.WriteString("DO UPDATE SET ")
-		sql.WriteString(strings.Join(replaces, ", "))
+		sql.WriteString(" ON CONFLICT ")
+		if target != "" {
+			sql.WriteString(target + " ")
+			args = append(args, targetArgs...)
+		}
+
+		if d.DoNothing {
+			sql.WriteString("DO NOTHING")
+		} else {
+			sql.WriteString("DO UPDATE SET ")
+			sql.WriteString(strings.Join(replaces, ", "))
+			args = append(args, replaceArgs...)
+
+			if d.UpdateWhere != "" {
+				sql.WriteString(" WHERE " + d.UpdateWhere)
+				args = append(args, d.UpdateArgs...)
+			}
+		}
+
+		if len(d.Returning) > 0 || d.Inserted {
+			sql.WriteString(" RETURNING " + d.returning())
+		}
 
 	} else if d.Dialect == MSSQL {
 		// IF NOT EXISTS (SELECT * FROM dbo.Table1 WHERE ID = @ID)
@@ -174,11 +429,21 @@ func (d *upsertData) ToSql() (sqlStr string, args []interface{}, err error) {
 		//        ItemQty = @ItemQty
 		//    WHERE ID = @ID
 
-		sql.WriteString("ELSE ")
-		sql.WriteString("UPDATE \"" + d.Into + "\" SET ")
-		sql.WriteString(strings.Join(replaces, ", "))
-		sql.WriteString(" WHERE \"" + d.Key[0].(string) + "\" = ?")
-		args = append(args, d.Key[1])
+		if !d.DoNothing {
+			sql.WriteString(" ELSE ")
+			sql.WriteString("UPDATE \"" + d.Into + "\" SET ")
+			sql.WriteString(strings.Join(replaces, ", "))
+			args = append(args, replaceArgs...)
+			if output != "" {
+				sql.WriteString(" " + output)
+			}
+			sql.WriteString(" WHERE " + match)
+			args = append(args, matchArgs...)
+			if d.UpdateWhere != "" {
+				sql.WriteString(" AND " + d.UpdateWhere)
+				args = append(args, d.UpdateArgs...)
+			}
+		}
 
 	} else {
 		err = ErrDialectNotSupported
@@ -187,19 +452,316 @@ func (d *upsertData) ToSql() (sqlStr string, args []interface{}, err error) {
 
 	sql.WriteString(";")
 
-	if d.Placeholder == "" {
+	sqlStr = ReplacePlaceholder(sql.String(), d.placeholder())
+	return
+}
+
+// placeholder returns the placeholder format, which defaults to the one of the dialect.
+func (d *upsertData) placeholder() string {
+	if d.Placeholder != "" {
+		return d.Placeholder
+	}
+	switch d.Dialect {
+	case PostgreSQL:
+		return Dollar
+	case MSSQL:
+		return AtP
+	default:
+		return Question
+	}
+}
+
+// merge reports whether the upsert is rendered as a MERGE statement, which is the case on MSSQL
+// unless the legacy Key is given with its value.
+func (d *upsertData) merge() bool {
+	return d.Dialect == MSSQL && len(d.Key) == 0
+}
+
+// mergeStatement renders the MERGE statement of MSSQL, along with its arguments.
+// HOLDLOCK keeps other sessions from inserting the same key between the match and the insert.
+func (d *upsertData) mergeStatement(replaces []string, replaceArgs []interface{}) (string, []interface{}, error) {
+	if len(d.Conflict) == 0 {
+		return "", nil, errors.New("unique key and value must be provided for MS SQL")
+	}
+
+	var conditions []string
+	for _, column := range d.Conflict {
+		if !isIn(d.Columns, column) {
+			return "", nil, errors.New("conflict column " + column + " must be one of the inserted columns")
+		}
+		conditions = append(conditions, "target.\""+column+"\" = src.\""+column+"\"")
+	}
+
+	source, args, err := d.source()
+	if err != nil {
+		return "", nil, err
+	}
+
+	var sources []string
+	for _, column := range d.Columns {
+		sources = append(sources, "src.\""+column+"\"")
+	}
+
+	var sql strings.Builder
+	sql.WriteString("MERGE INTO \"" + d.Into + "\" WITH (HOLDLOCK) AS target ")
+	sql.WriteString("USING (" + source + ") AS src (" + quoteColumns(d.Columns) + ") ")
+	sql.WriteString("ON " + strings.Join(conditions, " AND "))
+
+	if !d.DoNothing {
+		sql.WriteString(" WHEN MATCHED")
+		if d.UpdateWhere != "" {
+			sql.WriteString(" AND " + d.UpdateWhere)
+			args = append(args, d.UpdateArgs...)
+		}
+		sql.WriteString(" THEN UPDATE SET " + strings.Join(replaces, ", "))
+		args = append(args, replaceArgs...)
+	}
+
+	sql.WriteString(" WHEN NOT MATCHED THEN INSERT (" + quoteColumns(d.Columns) + ") VALUES (" + strings.Join(sources, ", ") + ")")
+
+	if output := d.output(); output != "" {
+		sql.WriteString(" " + output)
+	}
+
+	sql.WriteString(";")
+	return sql.String(), args, nil
+}
+
+// source renders the rows to be inserted, either as a VALUES list or as the query given with Select,
+// along with their arguments.
+func (d *upsertData) source() (string, []interface{}, error) {
+	if d.Select != nil {
+		return d.Select.ToSql()
+	}
+
+	var args []interface{}
+	var rows []string
+	for _, row := range d.Values {
+		var values []string
+		for _, v := range row {
+			value, valueArgs, err := renderValue(v)
+			if err != nil {
+				return "", nil, err
+			}
+			args = append(args, valueArgs...)
+			values = append(values, value)
+		}
+		rows = append(rows, "("+strings.Join(values, ", ")+")")
+	}
+
+	return "VALUES " + strings.Join(rows, ", "), args, nil
+}
+
+// records converts the maps and structs given by SetMap, Rows, Struct and Structs into values,
+// taking the columns from the first one if they were not given.
+func (d *upsertData) records() error {
+	values := append([][]interface{}(nil), d.Values...)
+	for _, record := range d.Records {
+		var columns []string
+		fields := make(map[string]interface{})
+
+		switch r := record.(type) {
+		case error:
+			return r
+		case map[string]interface{}:
+			for column, value := range r {
+				columns = append(columns, column)
+				fields[column] = value
+			}
+			sort.Strings(columns)
+		default:
+			list, err := structFields(r)
+			if err != nil {
+				return err
+			}
+			v := reflect.ValueOf(r)
+			for v.Kind() == reflect.Ptr {
+				if v.IsNil() {
+					return errors.New("a nil pointer can't be used as a row")
+				}
+				v = v.Elem()
+			}
+			for _, field := range list {
+				value := v.FieldByIndex(field.Index)
+				if !value.CanInterface() {
+					continue
+				}
+				columns = append(columns, field.Name)
+				fields[field.Name] = value.Interface()
+			}
+		}
+
+		if len(d.Columns) == 0 {
+			d.Columns = columns
+		}
+
+		var row []interface{}
+		for _, column := range d.Columns {
+			value, ok := fields[column]
+			if !ok {
+				return errors.New("column " + column + " is missing from the row")
+			}
+			row = append(row, value)
+		}
+
+		if _, ok := record.(map[string]interface{}); ok && len(fields) != len(d.Columns) {
+			return errors.New("the row has keys that are not part of the columns")
+		}
+
+		values = append(values, row)
+	}
+
+	d.Values = values
+	return nil
+}
+
+// assignments renders the assignments of the update, along with their arguments.
+func (d *upsertData) assignments() ([]string, []interface{}, error) {
+	var assignments []string
+	var args []interface{}
+	assign := func(column, value string, valueArgs []interface{}) {
+		quoted := "\"" + column + "\""
+		if d.Dialect == MySQL && d.UpdateWhere != "" {
+			// MySQL doesn't have a condition on ON DUPLICATE KEY UPDATE, so the old value is kept instead.
+			assignments = append(assignments, quoted+" = IF("+d.UpdateWhere+", "+value+", "+quoted+")")
+			args = append(args, d.UpdateArgs...)
+		} else {
+			assignments = append(assignments, quoted+" = "+value)
+		}
+		args = append(args, valueArgs...)
+	}
+
+	for i := 0; i < len(d.Replace); i++ {
+		value, valueArgs, err := renderValue(d.Replace[i][1])
+		if err != nil {
+			return nil, nil, err
+		}
+		assign(d.Replace[i][0].(string), value, valueArgs)
+	}
+
+	columns := append([]string(nil), d.FromInsert...)
+	if d.UpdateAll {
+		for _, column := range d.Columns {
+			if !isIn(d.Except, column) && !isIn(columns, column) {
+				columns = append(columns, column)
+			}
+		}
+	}
+
+	for _, column := range columns {
+		i := findPosition(d.Columns, column)
+		if i < 0 {
+			return nil, nil, errors.New("updated column " + column + " must be one of the inserted columns")
+		}
+
+		quoted := "\"" + column + "\""
 		switch d.Dialect {
+		case PostgreSQL, SQLite:
+			assign(column, "excluded."+quoted, nil)
 		case MySQL:
-			d.Placeholder = Question
-		case PostgreSQL:
-			d.Placeholder = Dollar
-		case SQLite:
-			d.Placeholder = Question
+			if d.RowAlias != "" {
+				assign(column, d.RowAlias+"."+quoted, nil)
+			} else {
+				assign(column, "VALUES("+quoted+")", nil)
+			}
 		case MSSQL:
-			d.Placeholder = AtP
+			if d.merge() {
+				assign(column, "src."+quoted, nil)
+				continue
+			}
+			// The existing row is updated on its own statement, so the value is taken from the inserted row.
+			if len(d.Values) > 1 || i >= len(d.Values[0]) {
+				return nil, nil, errors.New("MSSQL upsert only supports updating from a single inserted row")
+			}
+			value, valueArgs, err := renderValue(d.Values[0][i])
+			if err != nil {
+				return nil, nil, err
+			}
+			assign(column, value, valueArgs)
 		}
 	}
 
-	sqlStr = ReplacePlaceholder(sql.String(), d.Placeholder)
-	return
+	return assignments, args, nil
+}
+
+// returning renders the columns of the RETURNING clause.
+func (d *upsertData) returning() string {
+	var columns []string
+	for _, column := range d.Returning {
+		if column == "*" {
+			columns = append(columns, column)
+		} else {
+			columns = append(columns, "\""+column+"\"")
+		}
+	}
+	if d.Inserted {
+		columns = append(columns, "(xmax = 0) AS \"inserted\"")
+	}
+	return strings.Join(columns, ", ")
+}
+
+// output renders the OUTPUT clause of MSSQL.
+func (d *upsertData) output() string {
+	if d.Dialect != MSSQL || len(d.Returning) == 0 {
+		return ""
+	}
+
+	var columns []string
+	for _, column := range d.Returning {
+		if column == "*" {
+			columns = append(columns, "inserted.*")
+		} else {
+			columns = append(columns, "inserted.\""+column+"\"")
+		}
+	}
+	if d.merge() {
+		// $action tells whether the row was inserted or updated by the MERGE statement.
+		columns = append([]string{"$action"}, columns...)
+	}
+	return "OUTPUT " + strings.Join(columns, ", ")
+}
+
+// conflictColumns returns the columns of the unique key to be checked on conflict.
+func (d *upsertData) conflictColumns() []string {
+	if len(d.Conflict) > 0 {
+		return d.Conflict
+	}
+	if len(d.Key) > 0 {
+		return []string{d.Key[0].(string)}
+	}
+	return nil
+}
+
+// conflictTarget renders the conflict target of PostgreSQL and SQLite, along with its arguments.
+func (d *upsertData) conflictTarget() (string, []interface{}, error) {
+	if d.Constraint != "" {
+		if len(d.Key) > 0 || len(d.Conflict) > 0 || d.Where != "" {
+			return "", nil, errors.New("OnConstraint cannot be used along with Key, ConflictColumns or ConflictWhere")
+		}
+		return "ON CONSTRAINT \"" + d.Constraint + "\"", nil, nil
+	}
+
+	conflict := d.conflictColumns()
+	if len(conflict) == 0 {
+		if d.DoNothing && d.Where == "" {
+			// DO NOTHING doesn't need a conflict target, any unique violation is skipped.
+			return "", nil, nil
+		}
+		return "", nil, errors.New("unique key must be provided for PostgreSQL and SQLite")
+	}
+
+	target := "(" + quoteColumns(conflict) + ")"
+	if d.Where != "" {
+		target += " WHERE " + d.Where
+	}
+
+	return target, d.WhereArgs, nil
+}
+
+// matchRow renders the condition to find the existing row of the legacy Key on MSSQL, along with its arguments.
+func (d *upsertData) matchRow() (string, []interface{}, error) {
+	if len(d.Key) == 0 {
+		return "", nil, errors.New("unique key and value must be provided for MS SQL")
+	}
+	return "\"" + d.Key[0].(string) + "\" = ?", []interface{}{d.Key[1]}, nil
 }
@@ -224,3 +224,640 @@ func TestUpsert_WithoutReplacePlaceHolder(t *testing.T) {
 		}
 	})
 }
+
+func TestUpsert_ConflictColumns(t *testing.T) {
+	t.Run("PostgreSQL", func(t *testing.T) {
+		sql, args, err := bob.
+			Upsert("users", bob.PostgreSQL).
+			Columns("tenant_id", "email", "name").
+			Values(1, "john@doe.com", "John Doe").
+			ConflictColumns("tenant_id", "email").
+			Replace("name", "John Does").
+			ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		desiredSql := "INSERT INTO \"users\" (\"tenant_id\", \"email\", \"name\") VALUES ($1, $2, $3) ON CONFLICT (\"tenant_id\", \"email\") DO UPDATE SET \"name\" = $4;"
+		desiredArgs := []interface{}{1, "john@doe.com", "John Doe", "John Does"}
+
+		if sql != desiredSql {
+			t.Error("sql is not the same as result: ", sql)
+		}
+		if !reflect.DeepEqual(args, desiredArgs) {
+			t.Error("args is not the same as result: ", args)
+		}
+	})
+
+	t.Run("MSSQL", func(t *testing.T) {
+		sql, args, err := bob.
+			Upsert("users", bob.MSSQL).
+			Columns("tenant_id", "email", "name").
+			Values(1, "john@doe.com", "John Doe").
+			ConflictColumns("tenant_id", "email").
+			Replace("name", "John Does").
+			ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		desiredSql := "MERGE INTO \"users\" WITH (HOLDLOCK) AS target USING (VALUES (@p1, @p2, @p3)) AS src (\"tenant_id\", \"email\", \"name\") ON target.\"tenant_id\" = src.\"tenant_id\" AND target.\"email\" = src.\"email\" WHEN MATCHED THEN UPDATE SET \"name\" = @p4 WHEN NOT MATCHED THEN INSERT (\"tenant_id\", \"email\", \"name\") VALUES (src.\"tenant_id\", src.\"email\", src.\"name\");"
+		desiredArgs := []interface{}{1, "john@doe.com", "John Doe", "John Does"}
+
+		if sql != desiredSql {
+			t.Error("sql is not the same as result: ", sql)
+		}
+		if !reflect.DeepEqual(args, desiredArgs) {
+			t.Error("args is not the same as result: ", args)
+		}
+	})
+
+	t.Run("should emit error on conflict columns that are not inserted", func(t *testing.T) {
+		_, _, err := bob.Upsert("users", bob.MSSQL).Columns("name").Values("John Doe").ConflictColumns("email").Replace("name", "John Does").ToSql()
+		if err == nil || err.Error() != "conflict column email must be one of the inserted columns" {
+			t.Error("should throw an error, it didn't:", err)
+		}
+	})
+
+	t.Run("should emit error when used along with Key", func(t *testing.T) {
+		_, _, err := bob.Upsert("users", bob.PostgreSQL).Columns("email").Values("john@doe.com").Key("email").ConflictColumns("email").Replace("email", "john@doe.com").ToSql()
+		if err == nil || err.Error() != "Key and ConflictColumns cannot be used together" {
+			t.Error("should throw an error, it didn't:", err)
+		}
+	})
+}
+
+func TestUpsert_ConflictTarget(t *testing.T) {
+	t.Run("OnConstraint", func(t *testing.T) {
+		sql, args, err := bob.
+			Upsert("users", bob.PostgreSQL).
+			Columns("name", "email").
+			Values("John Doe", "john@doe.com").
+			OnConstraint("users_email_key").
+			Replace("name", "John Does").
+			ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		desiredSql := "INSERT INTO \"users\" (\"name\", \"email\") VALUES ($1, $2) ON CONFLICT ON CONSTRAINT \"users_email_key\" DO UPDATE SET \"name\" = $3;"
+		desiredArgs := []interface{}{"John Doe", "john@doe.com", "John Does"}
+
+		if sql != desiredSql {
+			t.Error("sql is not the same as result: ", sql)
+		}
+		if !reflect.DeepEqual(args, desiredArgs) {
+			t.Error("args is not the same as result: ", args)
+		}
+	})
+
+	t.Run("ConflictWhere", func(t *testing.T) {
+		sql, args, err := bob.
+			Upsert("users", bob.PostgreSQL).
+			Columns("name", "email").
+			Values("John Doe", "john@doe.com").
+			ConflictColumns("email").
+			ConflictWhere("deleted_at IS NULL AND tenant_id = ?", 7).
+			Replace("name", "John Does").
+			ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		desiredSql := "INSERT INTO \"users\" (\"name\", \"email\") VALUES ($1, $2) ON CONFLICT (\"email\") WHERE deleted_at IS NULL AND tenant_id = $3 DO UPDATE SET \"name\" = $4;"
+		desiredArgs := []interface{}{"John Doe", "john@doe.com", 7, "John Does"}
+
+		if sql != desiredSql {
+			t.Error("sql is not the same as result: ", sql)
+		}
+		if !reflect.DeepEqual(args, desiredArgs) {
+			t.Error("args is not the same as result: ", args)
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		cases := []struct {
+			builder bob.UpsertBuilder
+			err     string
+		}{
+			{bob.Upsert("users", bob.SQLite).OnConstraint("users_email_key"), "ON CONFLICT ON CONSTRAINT is only available for PostgreSQL"},
+			{bob.Upsert("users", bob.MySQL).ConflictWhere("deleted_at IS NULL"), "ConflictWhere is only available for PostgreSQL and SQLite"},
+			{bob.Upsert("users", bob.PostgreSQL).OnConstraint("users_email_key").ConflictColumns("email"), "OnConstraint cannot be used along with Key, ConflictColumns or ConflictWhere"},
+		}
+
+		for _, c := range cases {
+			_, _, err := c.builder.Columns("email").Values("john@doe.com").Replace("email", "john@doe.com").ToSql()
+			if err == nil || err.Error() != c.err {
+				t.Errorf("Expected %s, got %v", c.err, err)
+			}
+		}
+	})
+}
+
+func TestUpsert_DoNothing(t *testing.T) {
+	cases := []struct {
+		name        string
+		builder     bob.UpsertBuilder
+		desiredSql  string
+		desiredArgs []interface{}
+	}{
+		{
+			name:        "MySQL",
+			builder:     bob.Upsert("users", bob.MySQL).Columns("name", "email").Values("John Doe", "john@doe.com").DoNothing(),
+			desiredSql:  "INSERT INTO \"users\" (\"name\", \"email\") VALUES (?, ?) ON DUPLICATE KEY UPDATE \"name\" = \"name\";",
+			desiredArgs: []interface{}{"John Doe", "john@doe.com"},
+		},
+		{
+			name:        "PostgreSQL",
+			builder:     bob.Upsert("users", bob.PostgreSQL).Columns("name", "email").Values("John Doe", "john@doe.com").ConflictColumns("email").DoNothing(),
+			desiredSql:  "INSERT INTO \"users\" (\"name\", \"email\") VALUES ($1, $2) ON CONFLICT (\"email\") DO NOTHING;",
+			desiredArgs: []interface{}{"John Doe", "john@doe.com"},
+		},
+		{
+			name:        "SQLite without a conflict target",
+			builder:     bob.Upsert("users", bob.SQLite).Columns("name", "email").Values("John Doe", "john@doe.com").DoNothing(),
+			desiredSql:  "INSERT INTO \"users\" (\"name\", \"email\") VALUES (?, ?) ON CONFLICT DO NOTHING;",
+			desiredArgs: []interface{}{"John Doe", "john@doe.com"},
+		},
+		{
+			name:        "MSSQL",
+			builder:     bob.Upsert("users", bob.MSSQL).Columns("name", "email").Values("John Doe", "john@doe.com").ConflictColumns("email").DoNothing(),
+			desiredSql:  "MERGE INTO \"users\" WITH (HOLDLOCK) AS target USING (VALUES (@p1, @p2)) AS src (\"name\", \"email\") ON target.\"email\" = src.\"email\" WHEN NOT MATCHED THEN INSERT (\"name\", \"email\") VALUES (src.\"name\", src.\"email\");",
+			desiredArgs: []interface{}{"John Doe", "john@doe.com"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			sql, args, err := c.builder.ToSql()
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+
+			if sql != c.desiredSql {
+				t.Error("sql is not the same as result: ", sql)
+			}
+			if !reflect.DeepEqual(args, c.desiredArgs) {
+				t.Error("args is not the same as result: ", args)
+			}
+		})
+	}
+
+	t.Run("should emit error when used along with Replace", func(t *testing.T) {
+		_, _, err := bob.Upsert("users", bob.PostgreSQL).Columns("email").Values("john@doe.com").DoNothing().Replace("email", "john@doe.com").ToSql()
+		if err == nil || err.Error() != "DoNothing cannot be used along with Replace" {
+			t.Error("should throw an error, it didn't:", err)
+		}
+	})
+}
+
+func TestUpsert_UpdateFromInsert(t *testing.T) {
+	cases := []struct {
+		name        string
+		builder     bob.UpsertBuilder
+		desiredSql  string
+		desiredArgs []interface{}
+	}{
+		{
+			name:        "PostgreSQL",
+			builder:     bob.Upsert("users", bob.PostgreSQL).Columns("email", "name").Values("john@doe.com", "John Doe").Values("jane@doe.com", "Jane Doe").ConflictColumns("email").UpdateFromInsert("name"),
+			desiredSql:  "INSERT INTO \"users\" (\"email\", \"name\") VALUES ($1, $2), ($3, $4) ON CONFLICT (\"email\") DO UPDATE SET \"name\" = excluded.\"name\";",
+			desiredArgs: []interface{}{"john@doe.com", "John Doe", "jane@doe.com", "Jane Doe"},
+		},
+		{
+			name:        "SQLite",
+			builder:     bob.Upsert("users", bob.SQLite).Columns("email", "name", "age").Values("john@doe.com", "John Doe", 30).ConflictColumns("email").Replace("visits", 1).UpdateAllExcept("email"),
+			desiredSql:  "INSERT INTO \"users\" (\"email\", \"name\", \"age\") VALUES (?, ?, ?) ON CONFLICT (\"email\") DO UPDATE SET \"visits\" = ?, \"name\" = excluded.\"name\", \"age\" = excluded.\"age\";",
+			desiredArgs: []interface{}{"john@doe.com", "John Doe", 30, 1},
+		},
+		{
+			name:        "MySQL",
+			builder:     bob.Upsert("users", bob.MySQL).Columns("email", "name").Values("john@doe.com", "John Doe").UpdateFromInsert("name"),
+			desiredSql:  "INSERT INTO \"users\" (\"email\", \"name\") VALUES (?, ?) ON DUPLICATE KEY UPDATE \"name\" = VALUES(\"name\");",
+			desiredArgs: []interface{}{"john@doe.com", "John Doe"},
+		},
+		{
+			name:        "MySQL with a row alias",
+			builder:     bob.Upsert("users", bob.MySQL).Columns("email", "name").Values("john@doe.com", "John Doe").RowAlias("new").UpdateAllExcept("email"),
+			desiredSql:  "INSERT INTO \"users\" (\"email\", \"name\") VALUES (?, ?) AS new ON DUPLICATE KEY UPDATE \"name\" = new.\"name\";",
+			desiredArgs: []interface{}{"john@doe.com", "John Doe"},
+		},
+		{
+			name:        "MSSQL",
+			builder:     bob.Upsert("users", bob.MSSQL).Columns("email", "name").Values("john@doe.com", "John Doe").ConflictColumns("email").UpdateAllExcept("email"),
+			desiredSql:  "MERGE INTO \"users\" WITH (HOLDLOCK) AS target USING (VALUES (@p1, @p2)) AS src (\"email\", \"name\") ON target.\"email\" = src.\"email\" WHEN MATCHED THEN UPDATE SET \"name\" = src.\"name\" WHEN NOT MATCHED THEN INSERT (\"email\", \"name\") VALUES (src.\"email\", src.\"name\");",
+			desiredArgs: []interface{}{"john@doe.com", "John Doe"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			sql, args, err := c.builder.ToSql()
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+
+			if sql != c.desiredSql {
+				t.Error("sql is not the same as result: ", sql)
+			}
+			if !reflect.DeepEqual(args, c.desiredArgs) {
+				t.Error("args is not the same as result: ", args)
+			}
+		})
+	}
+
+	t.Run("Error", func(t *testing.T) {
+		cases := []struct {
+			builder bob.UpsertBuilder
+			err     string
+		}{
+			{bob.Upsert("users", bob.PostgreSQL).Key("email").UpdateFromInsert("age"), "updated column age must be one of the inserted columns"},
+			{bob.Upsert("users", bob.PostgreSQL).Key("email").UpdateAllExcept("email", "name"), "upsert statement must have at least one key value pair to be replaced"},
+			{bob.Upsert("users", bob.PostgreSQL).Key("email").UpdateAllExcept("email").DoNothing(), "DoNothing cannot be used along with UpdateFromInsert or UpdateAllExcept"},
+			{bob.Upsert("users", bob.PostgreSQL).Key("email").UpdateAllExcept("email").RowAlias("new"), "RowAlias is only available for MySQL"},
+		}
+
+		for _, c := range cases {
+			_, _, err := c.builder.Columns("email", "name").Values("john@doe.com", "John Doe").ToSql()
+			if err == nil || err.Error() != c.err {
+				t.Errorf("Expected %s, got %v", c.err, err)
+			}
+		}
+	})
+}
+
+type sqlizer struct{}
+
+func (sqlizer) ToSql() (string, []interface{}, error) {
+	return "(SELECT id FROM teams WHERE slug = ?)", []interface{}{"core"}, nil
+}
+
+func TestUpsert_Expr(t *testing.T) {
+	t.Run("PostgreSQL", func(t *testing.T) {
+		sql, args, err := bob.
+			Upsert("users", bob.PostgreSQL).
+			Columns("email", "team_id", "created_at").
+			Values("john@doe.com", sqlizer{}, bob.Expr("NOW()")).
+			ConflictColumns("email").
+			Replace("visits", bob.Expr("\"users\".\"visits\" + ?", 1)).
+			Replace("name", "John Does").
+			ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		desiredSql := "INSERT INTO \"users\" (\"email\", \"team_id\", \"created_at\") VALUES ($1, (SELECT id FROM teams WHERE slug = $2), NOW()) ON CONFLICT (\"email\") DO UPDATE SET \"visits\" = \"users\".\"visits\" + $3, \"name\" = $4;"
+		desiredArgs := []interface{}{"john@doe.com", "core", 1, "John Does"}
+
+		if sql != desiredSql {
+			t.Error("sql is not the same as result: ", sql)
+		}
+		if !reflect.DeepEqual(args, desiredArgs) {
+			t.Error("args is not the same as result: ", args)
+		}
+	})
+
+	t.Run("MSSQL", func(t *testing.T) {
+		sql, args, err := bob.
+			Upsert("users", bob.MSSQL).
+			Columns("email", "name").
+			Values(bob.Expr("LOWER(?)", "John@Doe.com"), "John Doe").
+			ConflictColumns("email").
+			Replace("updated_at", bob.Expr("GETDATE()")).
+			ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		desiredSql := "MERGE INTO \"users\" WITH (HOLDLOCK) AS target USING (VALUES (LOWER(@p1), @p2)) AS src (\"email\", \"name\") ON target.\"email\" = src.\"email\" WHEN MATCHED THEN UPDATE SET \"updated_at\" = GETDATE() WHEN NOT MATCHED THEN INSERT (\"email\", \"name\") VALUES (src.\"email\", src.\"name\");"
+		desiredArgs := []interface{}{"John@Doe.com", "John Doe"}
+
+		if sql != desiredSql {
+			t.Error("sql is not the same as result: ", sql)
+		}
+		if !reflect.DeepEqual(args, desiredArgs) {
+			t.Error("args is not the same as result: ", args)
+		}
+	})
+}
+
+func TestUpsert_Returning(t *testing.T) {
+	cases := []struct {
+		name        string
+		builder     bob.UpsertBuilder
+		desiredSql  string
+		desiredArgs []interface{}
+	}{
+		{
+			name:        "PostgreSQL",
+			builder:     bob.Upsert("users", bob.PostgreSQL).Columns("email", "name").Values("john@doe.com", "John Doe").ConflictColumns("email").UpdateFromInsert("name").Returning("id").ReturningInsertedFlag(),
+			desiredSql:  "INSERT INTO \"users\" (\"email\", \"name\") VALUES ($1, $2) ON CONFLICT (\"email\") DO UPDATE SET \"name\" = excluded.\"name\" RETURNING \"id\", (xmax = 0) AS \"inserted\";",
+			desiredArgs: []interface{}{"john@doe.com", "John Doe"},
+		},
+		{
+			name:        "SQLite",
+			builder:     bob.Upsert("users", bob.SQLite).Columns("email", "name").Values("john@doe.com", "John Doe").ConflictColumns("email").DoNothing().Returning("*"),
+			desiredSql:  "INSERT INTO \"users\" (\"email\", \"name\") VALUES (?, ?) ON CONFLICT (\"email\") DO NOTHING RETURNING *;",
+			desiredArgs: []interface{}{"john@doe.com", "John Doe"},
+		},
+		{
+			name:        "MSSQL",
+			builder:     bob.Upsert("users", bob.MSSQL).Columns("email", "name").Values("john@doe.com", "John Doe").Key("email", "john@doe.com").Replace("name", "John Does").Returning("id"),
+			desiredSql:  "IF NOT EXISTS (SELECT * FROM \"users\" WHERE \"email\" = @p1) INSERT INTO \"users\" (\"email\", \"name\") OUTPUT inserted.\"id\" VALUES (@p2, @p3) ELSE UPDATE \"users\" SET \"name\" = @p4 OUTPUT inserted.\"id\" WHERE \"email\" = @p5;",
+			desiredArgs: []interface{}{"john@doe.com", "john@doe.com", "John Doe", "John Does", "john@doe.com"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			sql, args, err := c.builder.ToSql()
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+
+			if sql != c.desiredSql {
+				t.Error("sql is not the same as result: ", sql)
+			}
+			if !reflect.DeepEqual(args, c.desiredArgs) {
+				t.Error("args is not the same as result: ", args)
+			}
+		})
+	}
+
+	t.Run("Error", func(t *testing.T) {
+		cases := []struct {
+			builder bob.UpsertBuilder
+			err     string
+		}{
+			{bob.Upsert("users", bob.MySQL).Returning("id"), "MySQL doesn't support returning the upserted rows, please use LAST_INSERT_ID() instead"},
+			{bob.Upsert("users", bob.SQLite).Key("email").ReturningInsertedFlag(), "ReturningInsertedFlag is only available for PostgreSQL"},
+		}
+
+		for _, c := range cases {
+			_, _, err := c.builder.Columns("email", "name").Values("john@doe.com", "John Doe").Replace("name", "John Does").ToSql()
+			if err == nil || err.Error() != c.err {
+				t.Errorf("Expected %s, got %v", c.err, err)
+			}
+		}
+	})
+}
+
+func TestUpsert_Merge(t *testing.T) {
+	t.Run("should merge multiple rows on a composite key", func(t *testing.T) {
+		sql, args, err := bob.
+			Upsert("users", bob.MSSQL).
+			Columns("tenant_id", "email", "name").
+			Values(1, "john@doe.com", "John Doe").
+			Values(1, "jane@doe.com", "Jane Doe").
+			ConflictColumns("tenant_id", "email").
+			UpdateFromInsert("name").
+			Returning("id").
+			ToSql()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		desiredSql := "MERGE INTO \"users\" WITH (HOLDLOCK) AS target USING (VALUES (@p1, @p2, @p3), (@p4, @p5, @p6)) AS src (\"tenant_id\", \"email\", \"name\") ON target.\"tenant_id\" = src.\"tenant_id\" AND target.\"email\" = src.\"email\" WHEN MATCHED THEN UPDATE SET \"name\" = src.\"name\" WHEN NOT MATCHED THEN INSERT (\"tenant_id\", \"email\", \"name\") VALUES (src.\"tenant_id\", src.\"email\", src.\"name\") OUTPUT $action, inserted.\"id\";"
+		desiredArgs := []interface{}{1, "john@doe.com", "John Doe", 1, "jane@doe.com", "Jane Doe"}
+
+		if sql != desiredSql {
+			t.Error("sql is not the same as result: ", sql)
+		}
+		if !reflect.DeepEqual(args, desiredArgs) {
+			t.Error("args is not the same as result: ", args)
+		}
+	})
+
+	t.Run("should emit error on rows with missing values", func(t *testing.T) {
+		_, _, err := bob.Upsert("users", bob.MSSQL).Columns("email", "name").Values("john@doe.com", "John Doe").Values("jane@doe.com").ConflictColumns("email").UpdateFromInsert("name").ToSql()
+		if err == nil || err.Error() != "every set of values must have the same length as the columns" {
+			t.Error("should throw an error, it didn't:", err)
+		}
+	})
+
+	t.Run("should emit error without conflict columns", func(t *testing.T) {
+		_, _, err := bob.Upsert("users", bob.MSSQL).Columns("email", "name").Values("john@doe.com", "John Doe").Replace("name", "John Does").ToSql()
+		if err == nil || err.Error() != "unique key and value must be provided for MS SQL" {
+			t.Error("should throw an error, it didn't:", err)
+		}
+	})
+}
+
+func TestUpsert_UpdateWhere(t *testing.T) {
+	cases := []struct {
+		name        string
+		builder     bob.UpsertBuilder
+		desiredSql  string
+		desiredArgs []interface{}
+	}{
+		{
+			name:        "PostgreSQL",
+			builder:     bob.Upsert("users", bob.PostgreSQL).Columns("email", "name", "version").Values("john@doe.com", "John Doe", 2).ConflictColumns("email").UpdateAllExcept("email").UpdateWhere(`"users"."version" < excluded."version"`).Returning("id"),
+			desiredSql:  "INSERT INTO \"users\" (\"email\", \"name\", \"version\") VALUES ($1, $2, $3) ON CONFLICT (\"email\") DO UPDATE SET \"name\" = excluded.\"name\", \"version\" = excluded.\"version\" WHERE \"users\".\"version\" < excluded.\"version\" RETURNING \"id\";",
+			desiredArgs: []interface{}{"john@doe.com", "John Doe", 2},
+		},
+		{
+			name:        "SQLite",
+			builder:     bob.Upsert("users", bob.SQLite).Columns("email", "name").Values("john@doe.com", "John Doe").ConflictColumns("email").Replace("name", "John Does").UpdateWhere(`"users"."locked" = ?`, false),
+			desiredSql:  "INSERT INTO \"users\" (\"email\", \"name\") VALUES (?, ?) ON CONFLICT (\"email\") DO UPDATE SET \"name\" = ? WHERE \"users\".\"locked\" = ?;",
+			desiredArgs: []interface{}{"john@doe.com", "John Doe", "John Does", false},
+		},
+		{
+			name:        "MySQL",
+			builder:     bob.Upsert("users", bob.MySQL).Columns("email", "name", "version").Values("john@doe.com", "John Doe", 2).Replace("name", "John Does").UpdateFromInsert("version").UpdateWhere(`"version" < ?`, 2),
+			desiredSql:  "INSERT INTO \"users\" (\"email\", \"name\", \"version\") VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE \"name\" = IF(\"version\" < ?, ?, \"name\"), \"version\" = IF(\"version\" < ?, VALUES(\"version\"), \"version\");",
+			desiredArgs: []interface{}{"john@doe.com", "John Doe", 2, 2, "John Does", 2},
+		},
+		{
+			name:        "MSSQL",
+			builder:     bob.Upsert("users", bob.MSSQL).Columns("email", "name").Values("john@doe.com", "John Doe").ConflictColumns("email").Replace("name", "John Does").UpdateWhere(`target."locked" = ?`, 0),
+			desiredSql:  "MERGE INTO \"users\" WITH (HOLDLOCK) AS target USING (VALUES (@p1, @p2)) AS src (\"email\", \"name\") ON target.\"email\" = src.\"email\" WHEN MATCHED AND target.\"locked\" = @p3 THEN UPDATE SET \"name\" = @p4 WHEN NOT MATCHED THEN INSERT (\"email\", \"name\") VALUES (src.\"email\", src.\"name\");",
+			desiredArgs: []interface{}{"john@doe.com", "John Doe", 0, "John Does"},
+		},
+		{
+			name:        "MSSQL with Key",
+			builder:     bob.Upsert("users", bob.MSSQL).Columns("email", "name").Values("john@doe.com", "John Doe").Key("email", "john@doe.com").Replace("name", "John Does").UpdateWhere(`"locked" = ?`, 0),
+			desiredSql:  "IF NOT EXISTS (SELECT * FROM \"users\" WHERE \"email\" = @p1) INSERT INTO \"users\" (\"email\", \"name\") VALUES (@p2, @p3) ELSE UPDATE \"users\" SET \"name\" = @p4 WHERE \"email\" = @p5 AND \"locked\" = @p6;",
+			desiredArgs: []interface{}{"john@doe.com", "john@doe.com", "John Doe", "John Does", "john@doe.com", 0},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			sql, args, err := c.builder.ToSql()
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+
+			if sql != c.desiredSql {
+				t.Error("sql is not the same as result: ", sql)
+			}
+			if !reflect.DeepEqual(args, c.desiredArgs) {
+				t.Error("args is not the same as result: ", args)
+			}
+		})
+	}
+
+	t.Run("should emit error when used along with DoNothing", func(t *testing.T) {
+		_, _, err := bob.Upsert("users", bob.PostgreSQL).Columns("email").Values("john@doe.com").ConflictColumns("email").DoNothing().UpdateWhere("true").ToSql()
+		if err == nil || err.Error() != "DoNothing cannot be used along with UpdateWhere" {
+			t.Error("should throw an error, it didn't:", err)
+		}
+	})
+}
+
+func TestUpsert_Records(t *testing.T) {
+	type base struct {
+		TenantID int `db:"tenant_id"`
+	}
+	type user struct {
+		base
+		Email    string `db:"email"`
+		Name     string
+		Password string `db:"-"`
+	}
+
+	cases := []struct {
+		name        string
+		builder     bob.UpsertBuilder
+		desiredSql  string
+		desiredArgs []interface{}
+	}{
+		{
+			name:        "SetMap",
+			builder:     bob.Upsert("users", bob.PostgreSQL).SetMap(map[string]interface{}{"name": "John Doe", "email": "john@doe.com", "age": 30}).ConflictColumns("email").UpdateAllExcept("email"),
+			desiredSql:  "INSERT INTO \"users\" (\"age\", \"email\", \"name\") VALUES ($1, $2, $3) ON CONFLICT (\"email\") DO UPDATE SET \"age\" = excluded.\"age\", \"name\" = excluded.\"name\";",
+			desiredArgs: []interface{}{30, "john@doe.com", "John Doe"},
+		},
+		{
+			name: "Rows",
+			builder: bob.Upsert("users", bob.MySQL).Columns("email", "name").Rows([]map[string]interface{}{
+				{"name": "John Doe", "email": "john@doe.com"},
+				{"email": "jane@doe.com", "name": "Jane Doe"},
+			}).UpdateFromInsert("name"),
+			desiredSql:  "INSERT INTO \"users\" (\"email\", \"name\") VALUES (?, ?), (?, ?) ON DUPLICATE KEY UPDATE \"name\" = VALUES(\"name\");",
+			desiredArgs: []interface{}{"john@doe.com", "John Doe", "jane@doe.com", "Jane Doe"},
+		},
+		{
+			name:        "Struct",
+			builder:     bob.Upsert("users", bob.SQLite).Struct(&user{base: base{TenantID: 1}, Email: "john@doe.com", Name: "John Doe", Password: "secret"}).ConflictColumns("tenant_id", "email").UpdateFromInsert("name"),
+			desiredSql:  "INSERT INTO \"users\" (\"tenant_id\", \"email\", \"name\") VALUES (?, ?, ?) ON CONFLICT (\"tenant_id\", \"email\") DO UPDATE SET \"name\" = excluded.\"name\";",
+			desiredArgs: []interface{}{1, "john@doe.com", "John Doe"},
+		},
+		{
+			name: "Structs",
+			builder: bob.Upsert("users", bob.MSSQL).Columns("email", "name").Structs([]user{
+				{Email: "john@doe.com", Name: "John Doe"},
+				{Email: "jane@doe.com", Name: "Jane Doe"},
+			}).ConflictColumns("email").UpdateFromInsert("name"),
+			desiredSql:  "MERGE INTO \"users\" WITH (HOLDLOCK) AS target USING (VALUES (@p1, @p2), (@p3, @p4)) AS src (\"email\", \"name\") ON target.\"email\" = src.\"email\" WHEN MATCHED THEN UPDATE SET \"name\" = src.\"name\" WHEN NOT MATCHED THEN INSERT (\"email\", \"name\") VALUES (src.\"email\", src.\"name\");",
+			desiredArgs: []interface{}{"john@doe.com", "John Doe", "jane@doe.com", "Jane Doe"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			sql, args, err := c.builder.ToSql()
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+
+			if sql != c.desiredSql {
+				t.Error("sql is not the same as result: ", sql)
+			}
+			if !reflect.DeepEqual(args, c.desiredArgs) {
+				t.Error("args is not the same as result: ", args)
+			}
+		})
+	}
+
+	t.Run("Error", func(t *testing.T) {
+		cases := []struct {
+			builder bob.UpsertBuilder
+			err     string
+		}{
+			{bob.Upsert("users", bob.PostgreSQL).Columns("email", "name").Values("john@doe.com"), "every set of values must have the same length as the columns"},
+			{bob.Upsert("users", bob.PostgreSQL).SetMap(map[string]interface{}{"email": "john@doe.com"}).SetMap(map[string]interface{}{"name": "Jane Doe"}), "column email is missing from the row"},
+			{bob.Upsert("users", bob.PostgreSQL).Columns("email").SetMap(map[string]interface{}{"email": "john@doe.com", "name": "John Doe"}), "the row has keys that are not part of the columns"},
+			{bob.Upsert("users", bob.PostgreSQL).Struct("john@doe.com"), "a struct or a pointer to a struct must be provided"},
+			{bob.Upsert("users", bob.PostgreSQL).Structs(user{}), "Structs must be given a slice of structs"},
+		}
+
+		for _, c := range cases {
+			_, _, err := c.builder.ConflictColumns("email").Replace("name", "John Does").ToSql()
+			if err == nil || err.Error() != c.err {
+				t.Errorf("Expected %s, got %v", c.err, err)
+			}
+		}
+	})
+}
+
+func TestUpsert_Select(t *testing.T) {
+	query := bob.Expr(`SELECT "email", "name" FROM "imported_users" WHERE "batch" = ?`, 7)
+
+	cases := []struct {
+		name        string
+		builder     bob.UpsertBuilder
+		desiredSql  string
+		desiredArgs []interface{}
+	}{
+		{
+			name:        "PostgreSQL",
+			builder:     bob.Upsert("users", bob.PostgreSQL).Columns("email", "name").Select(query).ConflictColumns("email").Replace("imported", true).UpdateFromInsert("name"),
+			desiredSql:  "INSERT INTO \"users\" (\"email\", \"name\") SELECT \"email\", \"name\" FROM \"imported_users\" WHERE \"batch\" = $1 ON CONFLICT (\"email\") DO UPDATE SET \"imported\" = $2, \"name\" = excluded.\"name\";",
+			desiredArgs: []interface{}{7, true},
+		},
+		{
+			name:        "SQLite",
+			builder:     bob.Upsert("users", bob.SQLite).Columns("email", "name").Select(query).ConflictColumns("email").DoNothing(),
+			desiredSql:  "INSERT INTO \"users\" (\"email\", \"name\") SELECT \"email\", \"name\" FROM \"imported_users\" WHERE \"batch\" = ? ON CONFLICT (\"email\") DO NOTHING;",
+			desiredArgs: []interface{}{7},
+		},
+		{
+			name:        "MySQL",
+			builder:     bob.Upsert("users", bob.MySQL).Columns("email", "name").Select(query).UpdateFromInsert("name"),
+			desiredSql:  "INSERT INTO \"users\" (\"email\", \"name\") SELECT \"email\", \"name\" FROM \"imported_users\" WHERE \"batch\" = ? ON DUPLICATE KEY UPDATE \"name\" = VALUES(\"name\");",
+			desiredArgs: []interface{}{7},
+		},
+		{
+			name:        "MSSQL",
+			builder:     bob.Upsert("users", bob.MSSQL).Columns("email", "name").Select(query).ConflictColumns("email").Replace("imported", 1).UpdateFromInsert("name"),
+			desiredSql:  "MERGE INTO \"users\" WITH (HOLDLOCK) AS target USING (SELECT \"email\", \"name\" FROM \"imported_users\" WHERE \"batch\" = @p1) AS src (\"email\", \"name\") ON target.\"email\" = src.\"email\" WHEN MATCHED THEN UPDATE SET \"imported\" = @p2, \"name\" = src.\"name\" WHEN NOT MATCHED THEN INSERT (\"email\", \"name\") VALUES (src.\"email\", src.\"name\");",
+			desiredArgs: []interface{}{7, 1},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			sql, args, err := c.builder.ToSql()
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+
+			if sql != c.desiredSql {
+				t.Error("sql is not the same as result: ", sql)
+			}
+			if !reflect.DeepEqual(args, c.desiredArgs) {
+				t.Error("args is not the same as result: ", args)
+			}
+		})
+	}
+
+	t.Run("Error", func(t *testing.T) {
+		cases := []struct {
+			builder bob.UpsertBuilder
+			err     string
+		}{
+			{bob.Upsert("users", bob.PostgreSQL).Values("john@doe.com", "John Doe").ConflictColumns("email"), "Select cannot be used along with Values"},
+			{bob.Upsert("users", bob.MySQL).RowAlias("new"), "RowAlias cannot be used along with Select"},
+			{bob.Upsert("users", bob.MSSQL).Key("email", "john@doe.com"), "MSSQL upsert from Select must use ConflictColumns instead of Key"},
+		}
+
+		for _, c := range cases {
+			_, _, err := c.builder.Columns("email", "name").Select(query).Replace("name", "John Does").ToSql()
+			if err == nil || err.Error() != c.err {
+				t.Errorf("Expected %s, got %v", c.err, err)
+			}
+		}
+	})
+}